@@ -0,0 +1,219 @@
+// Command setop performs set operations on line-based files.
+//
+// Usage:
+//
+//	setop [flags] <operation> FILE...
+//
+// The operations are:
+//
+//	dedup     elements of all files, each printed once
+//	union     elements found in any file
+//	intersect elements found in every file
+//	diff      elements of the first file that are not in any other file
+//	symdiff   elements found in an odd number of files
+//	subset    exits with status 1 unless every element of the first file is in the others
+//	equal     exits with status 1 unless every file holds the same elements
+//
+// A FILE of "-" reads the standard input. Elements are compared byte by byte and,
+// unless -ordered is given, printed in byte order so the output does not depend on
+// the locale. Errors exit with status 2.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+)
+
+const usage = `usage: setop [flags] <operation> FILE...
+
+operations:
+  dedup      elements of all files, each printed once
+  union      elements found in any file
+  intersect  elements found in every file
+  diff       elements of the first file that are not in any other file
+  symdiff    elements found in an odd number of files
+  subset     exit with status 1 unless the first file is a subset of the others
+  equal      exit with status 1 unless every file holds the same elements
+
+flags:
+`
+
+type options struct {
+	ordered   bool
+	count     bool
+	delimiter string
+	csv       bool
+	column    int
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var opts options
+
+	fs := flag.NewFlagSet("setop", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.BoolVar(&opts.ordered, "ordered", false, "print elements in the order they were first seen instead of byte order")
+	fs.BoolVar(&opts.count, "count", false, "print the number of resulting elements instead of the elements")
+	fs.StringVar(&opts.delimiter, "d", `\n`, "element delimiter, Go escape sequences are allowed")
+	fs.BoolVar(&opts.csv, "csv", false, "read the input as CSV and use the field selected by -column")
+	fs.IntVar(&opts.column, "column", 1, "CSV column holding the elements, starting at 1")
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	delim, err := strconv.Unquote(`"` + opts.delimiter + `"`)
+	if err != nil || delim == "" {
+		fmt.Fprintf(stderr, "setop: invalid delimiter %q\n", opts.delimiter)
+		return 2
+	}
+	opts.delimiter = delim
+
+	if opts.csv && opts.column < 1 {
+		fmt.Fprintf(stderr, "setop: invalid column %d\n", opts.column)
+		return 2
+	}
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return 2
+	}
+
+	op, ok := operations[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "setop: unknown operation %q\n", fs.Arg(0))
+		return 2
+	}
+
+	files := fs.Args()[1:]
+	if len(files) < op.minFiles {
+		fmt.Fprintf(stderr, "setop: %s needs at least %d files\n", fs.Arg(0), op.minFiles)
+		return 2
+	}
+
+	inputs := make([]lineSet, 0, len(files))
+	for _, name := range files {
+		s, err := readFile(name, stdin, opts)
+		if err != nil {
+			fmt.Fprintf(stderr, "setop: %v\n", err)
+			return 2
+		}
+		inputs = append(inputs, s)
+	}
+
+	if op.check != nil {
+		if !op.check(inputs) {
+			return 1
+		}
+		return 0
+	}
+
+	result := op.apply(inputs)
+
+	w := bufio.NewWriter(stdout)
+	if opts.count {
+		fmt.Fprintln(w, len(result))
+	} else {
+		if !opts.ordered {
+			slices.Sort(result)
+		}
+		for _, k := range result {
+			w.WriteString(k)
+			w.WriteString(opts.delimiter)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(stderr, "setop: %v\n", err)
+		return 2
+	}
+
+	return 0
+}
+
+func readFile(name string, stdin io.Reader, opts options) (lineSet, error) {
+	r := stdin
+	if name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	s := newLineSet(opts.ordered)
+	if err := read(r, s, opts); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return s, nil
+}
+
+func read(r io.Reader, s lineSet, opts options) error {
+	if opts.csv {
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.ReuseRecord = true
+
+		for {
+			record, err := cr.Read()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			if opts.column > len(record) {
+				line, _ := cr.FieldPos(0)
+				return fmt.Errorf("line %d: no column %d", line, opts.column)
+			}
+			s.Insert(record[opts.column-1])
+		}
+	}
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	sc.Split(splitOn([]byte(opts.delimiter)))
+
+	for sc.Scan() {
+		k := sc.Bytes()
+		if opts.delimiter == "\n" {
+			k = bytes.TrimSuffix(k, []byte{'\r'})
+		}
+		s.Insert(string(k))
+	}
+
+	return sc.Err()
+}
+
+func splitOn(delim []byte) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		if i := bytes.Index(data, delim); i >= 0 {
+			return i + len(delim), data[:i], nil
+		}
+		if atEOF && len(data) > 0 {
+			return len(data), data, nil
+		}
+		return 0, nil, nil
+	}
+}
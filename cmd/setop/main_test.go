@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFiles(t *testing.T, contents ...string) []string {
+	t.Helper()
+
+	dir := t.TempDir()
+	names := make([]string, 0, len(contents))
+	for i, content := range contents {
+		name := filepath.Join(dir, string(rune('a'+i)))
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func TestRun(t *testing.T) {
+	files := writeFiles(t, "c\nb\na\nb\n", "d\r\nb\r\nc\r\n", "c\n")
+
+	tests := []struct {
+		args   []string
+		expect string
+		code   int
+	}{
+		{args: []string{"dedup", files[0]}, expect: "a\nb\nc\n"},
+		{args: []string{"-ordered", "dedup", files[0]}, expect: "c\nb\na\n"},
+		{args: []string{"union", files[0], files[1]}, expect: "a\nb\nc\nd\n"},
+		{args: []string{"intersect", files[0], files[1], files[2]}, expect: "c\n"},
+		{args: []string{"diff", files[0], files[1]}, expect: "a\n"},
+		{args: []string{"symdiff", files[0], files[1]}, expect: "a\nd\n"},
+		{args: []string{"symdiff", files[0], files[1], files[2]}, expect: "a\nc\nd\n"},
+		{args: []string{"-count", "union", files[0], files[1]}, expect: "4\n"},
+		{args: []string{"subset", files[2], files[0]}, code: 0},
+		{args: []string{"subset", files[0], files[2]}, code: 1},
+		{args: []string{"equal", files[0], files[0]}, code: 0},
+		{args: []string{"equal", files[0], files[1]}, code: 1},
+		{args: []string{"nope", files[0]}, code: 2},
+		{args: []string{"union", "does-not-exist"}, code: 2},
+	}
+
+	for i, test := range tests {
+		var stdout, stderr bytes.Buffer
+		code := run(test.args, strings.NewReader(""), &stdout, &stderr)
+
+		if code != test.code {
+			t.Fatalf("Index: %d, Expected: exit %d, Got: exit %d (%s)", i, test.code, code, stderr.String())
+		}
+
+		if stdout.String() != test.expect {
+			t.Fatalf("Index: %d, Expected: %q, Got: %q", i, test.expect, stdout.String())
+		}
+	}
+}
+
+func TestRunDelimiterAndCSV(t *testing.T) {
+	tests := []struct {
+		args   []string
+		stdin  string
+		expect string
+	}{
+		{
+			args:   []string{"-d", `\t`, "-ordered", "dedup", "-"},
+			stdin:  "x\ty\tx",
+			expect: "x\ty\t",
+		},
+		{
+			args:   []string{"-csv", "-column", "2", "dedup", "-"},
+			stdin:  "1,\"b, c\"\n2,a\n3,\"b, c\"\n",
+			expect: "a\nb, c\n",
+		},
+	}
+
+	for i, test := range tests {
+		var stdout, stderr bytes.Buffer
+		if code := run(test.args, strings.NewReader(test.stdin), &stdout, &stderr); code != 0 {
+			t.Fatalf("Index: %d, Expected: exit 0, Got: exit %d (%s)", i, code, stderr.String())
+		}
+
+		if stdout.String() != test.expect {
+			t.Fatalf("Index: %d, Expected: %q, Got: %q", i, test.expect, stdout.String())
+		}
+	}
+}
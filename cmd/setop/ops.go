@@ -0,0 +1,114 @@
+package main
+
+import (
+	"iter"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/orderedset"
+)
+
+// lineSet is the part of the API shared by `set.Set` and `orderedset.OrderedSet`.
+type lineSet interface {
+	Insert(k string)
+	Contains(k string) bool
+	Len() int
+	All() iter.Seq[string]
+}
+
+func newLineSet(ordered bool) lineSet {
+	if ordered {
+		return orderedset.New[string]()
+	}
+	return set.New[string]()
+}
+
+type operation struct {
+	minFiles int
+	apply    func(inputs []lineSet) []string
+	check    func(inputs []lineSet) bool
+}
+
+var operations = map[string]operation{
+	"dedup":     {minFiles: 1, apply: union},
+	"union":     {minFiles: 1, apply: union},
+	"intersect": {minFiles: 1, apply: intersect},
+	"diff":      {minFiles: 1, apply: diff},
+	"symdiff":   {minFiles: 1, apply: symdiff},
+	"subset":    {minFiles: 2, check: subset},
+	"equal":     {minFiles: 2, check: equal},
+}
+
+// collect walks every element of the inputs once, in the order they were first
+// seen, and keeps those for which keep returns true.
+func collect(inputs []lineSet, keep func(k string, from int) bool) []string {
+	seen := set.New[string]()
+	var result []string
+
+	for i, s := range inputs {
+		for k := range s.All() {
+			if seen.Contains(k) {
+				continue
+			}
+			seen.Insert(k)
+
+			if keep(k, i) {
+				result = append(result, k)
+			}
+		}
+	}
+
+	return result
+}
+
+func union(inputs []lineSet) []string {
+	return collect(inputs, func(string, int) bool {
+		return true
+	})
+}
+
+func intersect(inputs []lineSet) []string {
+	return collect(inputs[:1], func(k string, _ int) bool {
+		for _, s := range inputs[1:] {
+			if !s.Contains(k) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func diff(inputs []lineSet) []string {
+	return collect(inputs[:1], func(k string, _ int) bool {
+		for _, s := range inputs[1:] {
+			if s.Contains(k) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func symdiff(inputs []lineSet) []string {
+	return collect(inputs, func(k string, from int) bool {
+		n := 1
+		for _, s := range inputs[from+1:] {
+			if s.Contains(k) {
+				n++
+			}
+		}
+		return n%2 == 1
+	})
+}
+
+func subset(inputs []lineSet) bool {
+	return len(diff(inputs)) == 0
+}
+
+func equal(inputs []lineSet) bool {
+	for _, s := range inputs[1:] {
+		if s.Len() != inputs[0].Len() {
+			return false
+		}
+	}
+	return len(intersect(inputs)) == inputs[0].Len()
+}
@@ -0,0 +1,121 @@
+package set
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/Jamlie/set/internal"
+)
+
+// Returns a sequence of every subset of `s`, from the smallest to the largest.
+//
+// The subsets are generated as they are iterated, so the 2^n subsets of a large set
+// are never held in memory at once. Each subset is a new set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		for subset := range set.PowerSet(set.FromSlice([]string{"a", "b"})) {
+//			fmt.Println(subset) // [], [a], [b], [a b]
+//		}
+//	}
+func PowerSet[T comparable](s *Set[T]) iter.Seq[*Set[T]] {
+	return internal.Collect(PowerSetInto(s, nil), FromSlice)
+}
+
+// Returns a sequence of every subset of `s` as a slice, from the smallest to the largest.
+//
+// Every subset is written into the same slice, starting with `buf` and only growing it
+// when it's too small, so nothing is allocated per subset. The slice is only valid
+// until the next iteration.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.FromSlice([]int{1, 2, 3})
+//		buf := make([]int, 0, v.Len())
+//
+//		for subset := range set.PowerSetInto(v, buf) {
+//			fmt.Println(subset)
+//		}
+//	}
+func PowerSetInto[T comparable](s *Set[T], buf []T) iter.Seq[[]T] {
+	return internal.PowerSetInto(s.Keys, buf)
+}
+
+// Returns a sequence of every subset of `s` with `k` elements. Each subset is a new set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		flags := set.FromSlice([]string{"cache", "gzip", "tls"})
+//		for pair := range set.Combinations(flags, 2) {
+//			fmt.Println(pair)
+//		}
+//	}
+func Combinations[T comparable](s *Set[T], k int) iter.Seq[*Set[T]] {
+	return internal.Collect(CombinationsInto(s, k, nil), FromSlice)
+}
+
+// Returns a sequence of every subset of `s` with `k` elements as a slice, written into
+// `buf` as with PowerSetInto.
+func CombinationsInto[T comparable](s *Set[T], k int, buf []T) iter.Seq[[]T] {
+	return internal.CombinationsInto(s.Keys, k, buf)
+}
+
+// Returns a sequence of every tuple made of one element of each set, in order. Each
+// tuple is a new slice.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		oses := set.FromSlice([]string{"linux", "darwin"})
+//		archs := set.FromSlice([]string{"amd64", "arm64"})
+//
+//		for tuple := range set.CartesianProduct(oses, archs) {
+//			fmt.Println(tuple) // 4 tuples such as [linux arm64]
+//		}
+//	}
+func CartesianProduct[T comparable](sets ...*Set[T]) iter.Seq[[]T] {
+	return internal.Collect(CartesianProductInto(nil, sets...), slices.Clone)
+}
+
+// Returns a sequence of every tuple made of one element of each set, written into
+// `buf` as with PowerSetInto.
+func CartesianProductInto[T comparable](buf []T, sets ...*Set[T]) iter.Seq[[]T] {
+	return internal.ProductInto(sets, (*Set[T]).Keys, buf)
+}
+
+// pick fills `buf` with the keys at the indices `idx`.
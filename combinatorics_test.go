@@ -0,0 +1,83 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/Jamlie/set"
+)
+
+func TestPowerSetAndCombinations(t *testing.T) {
+	s := set.FromSlice([]int{1, 2, 3, 4, 5})
+
+	tests := []struct {
+		k     int
+		count int
+	}{
+		{k: -1, count: 0},
+		{k: 0, count: 1},
+		{k: 2, count: 10},
+		{k: 5, count: 1},
+		{k: 6, count: 0},
+	}
+
+	for i, test := range tests {
+		seen := set.NewSetOfSets[int]()
+		for c := range set.Combinations(s, test.k) {
+			if c.Len() != test.k {
+				t.Fatalf("Index: %d, Expected: %d elements, Got: %s", i, test.k, c)
+			}
+			seen.Insert(c)
+		}
+
+		if seen.Len() != test.count {
+			t.Fatalf("Index: %d, Expected: %d, Got: %d", i, test.count, seen.Len())
+		}
+	}
+
+	size := -1
+	count := 0
+	for subset := range set.PowerSet(s) {
+		if subset.Len() < size {
+			t.Fatalf("Expected: subsets from the smallest, Got: %s after size %d", subset, size)
+		}
+		size = subset.Len()
+		count++
+	}
+
+	if count != 32 {
+		t.Fatalf("Expected: 32, Got: %d", count)
+	}
+}
+
+func TestCartesianProduct(t *testing.T) {
+	a := set.FromSlice([]string{"linux", "darwin", "windows"})
+	b := set.FromSlice([]string{"amd64", "arm64"})
+
+	tuples := set.New[[2]string]()
+	for tuple := range set.CartesianProduct(a, b) {
+		tuples.Insert([2]string(tuple))
+	}
+
+	if tuples.Len() != 6 || !tuples.Contains([2]string{"windows", "arm64"}) {
+		t.Fatalf("Expected: 6 tuples, Got: %s", tuples)
+	}
+
+	for range set.CartesianProduct(a, set.New[string]()) {
+		t.Fatalf("Expected: no tuples with an empty set")
+	}
+
+	x, y := set.New[int](), set.New[int]()
+	for i := range 100 {
+		x.Insert(i)
+		y.Insert(-i)
+	}
+
+	buf := make([]int, 0, 2)
+	allocs := testing.AllocsPerRun(10, func() {
+		for range set.CartesianProductInto(buf, x, y) {
+		}
+	})
+	if allocs > 20 {
+		t.Fatalf("Expected: allocations independent of the tuples, Got: %v", allocs)
+	}
+}
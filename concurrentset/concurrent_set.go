@@ -10,11 +10,13 @@ package concurrentset
 
 import (
 	"fmt"
-	"maps"
 	"sync"
+	"sync/atomic"
+
+	"github.com/Jamlie/set"
 )
 
-// A `ConcurrentSet` is implemented as a `map[T]struct{}` and an `RWMutex`.
+// A `ConcurrentSet` is implemented as a `set.Set[T]` and an `RWMutex`.
 //
 // As with maps, a ConcurrentSet requires T to be a comparable, meaning it can
 // accept structs if and only if they don't have a type
@@ -76,13 +78,21 @@ import (
 //		fmt.Println(uniquePeople)
 //	}
 type ConcurrentSet[T comparable] struct {
-	set map[T]struct{}
+	set *set.Set[T]
 	mu  sync.RWMutex
+	// shared is set while a Snapshot refers to set, so the next write copies it first.
+	shared atomic.Bool
+	// changed is closed on the next write to wake up waiters, see wait.
+	changed chan struct{}
+	// version is incremented on every write, see Tx.
+	version uint64
+	// fingerprint caches the result of Fingerprint for a version.
+	fingerprint atomic.Pointer[fingerprint]
 }
 
 func New[T comparable]() *ConcurrentSet[T] {
 	return &ConcurrentSet[T]{
-		set: make(map[T]struct{}),
+		set: set.New[T](),
 	}
 }
 
@@ -96,36 +106,44 @@ func WithCapacity[T comparable](capacity int) *ConcurrentSet[T] {
 	}
 
 	return &ConcurrentSet[T]{
-		set: make(map[T]struct{}, capacity),
+		set: set.WithCapacity[T](capacity),
 	}
 }
 
 func (s *ConcurrentSet[T]) Insert(k T) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.set[k] = struct{}{}
+	if !s.set.Contains(k) {
+		s.own().Insert(k)
+	}
 }
 
 func (s *ConcurrentSet[T]) Delete(k T) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	delete(s.set, k)
+	if s.set.Contains(k) {
+		s.own().Delete(k)
+	}
 }
 
 func (s *ConcurrentSet[T]) Len() int {
-	return len(s.set)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Len()
 }
 
 func (s *ConcurrentSet[T]) Contains(k T) bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	_, ok := s.set[k]
-	return ok
+	return s.set.Contains(k)
 }
 
 func (s *ConcurrentSet[T]) Clone() *ConcurrentSet[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return &ConcurrentSet[T]{
-		set: maps.Clone(s.set),
+		set: s.set.Clone(),
 	}
 }
 
@@ -133,19 +151,15 @@ func (s *ConcurrentSet[T]) Keys() []T {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	keys := make([]T, 0, s.Len())
-
-	for k := range s.set {
-		keys = append(keys, k)
-	}
-
-	return keys
+	return s.set.Keys()
 }
 
 func (s *ConcurrentSet[T]) Clear() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	clear(s.set)
+	if !s.set.Empty() {
+		s.replace(set.New[T]())
+	}
 }
 
 func (s *ConcurrentSet[T]) Empty() bool {
@@ -156,6 +170,26 @@ func (s *ConcurrentSet[T]) String() string {
 	return fmt.Sprint(s.Keys())
 }
 
+// own returns the set, copying it first if a snapshot refers to it.
+// It must be called with the write lock held, before modifying the set.
+func (s *ConcurrentSet[T]) own() *set.Set[T] {
+	s.version++
+	s.notify()
+	if s.shared.Load() {
+		s.set = s.set.Clone()
+		s.shared.Store(false)
+	}
+	return s.set
+}
+
+// replace swaps the underlying set, it must be called with the write lock held.
+func (s *ConcurrentSet[T]) replace(newSet *set.Set[T]) {
+	s.version++
+	s.notify()
+	s.set = newSet
+	s.shared.Store(false)
+}
+
 func (s *ConcurrentSet[T]) Iter() *concurrentSetIter[T] {
 	return &concurrentSetIter[T]{
 		set:         s,
@@ -0,0 +1,119 @@
+package concurrentset
+
+import (
+	"slices"
+
+	"github.com/Jamlie/set"
+)
+
+// Inserts `k` and returns `true`, or returns `false` if it was already in the set.
+func (s *ConcurrentSet[T]) InsertIfAbsent(k T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.set.Contains(k) {
+		return false
+	}
+
+	s.own().Insert(k)
+	return true
+}
+
+// Deletes `k` and returns `true`, or returns `false` if it was not in the set.
+func (s *ConcurrentSet[T]) DeleteIfPresent(k T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.set.Contains(k) {
+		return false
+	}
+
+	s.own().Delete(k)
+	return true
+}
+
+// Replaces `old` with `new` if `old` is in the set and `new` is not, and returns
+// whether the set was changed.
+func (s *ConcurrentSet[T]) Swap(old, new T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.set.Contains(old) || s.set.Contains(new) {
+		return false
+	}
+
+	tx := s.own()
+	tx.Delete(old)
+	tx.Insert(new)
+	return true
+}
+
+// Inserts every value and returns how many of them were not already in the set.
+func (s *ConcurrentSet[T]) InsertAll(vs ...T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(vs, func(k T) bool {
+		return !s.set.Contains(k)
+	})
+	if i < 0 {
+		return 0
+	}
+
+	tx := s.own()
+	before := tx.Len()
+	for _, k := range vs[i:] {
+		tx.Insert(k)
+	}
+
+	return tx.Len() - before
+}
+
+// Deletes every value and returns how many of them were in the set.
+func (s *ConcurrentSet[T]) DeleteAll(vs ...T) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := slices.IndexFunc(vs, s.set.Contains)
+	if i < 0 {
+		return 0
+	}
+
+	tx := s.own()
+	before := tx.Len()
+	for _, k := range vs[i:] {
+		tx.Delete(k)
+	}
+
+	return before - tx.Len()
+}
+
+// Runs `fn` while holding the write lock, giving it direct access to the underlying set.
+//
+// The set passed to `fn` must not be kept or used once `fn` returns, and `fn` must not
+// call any method of the ConcurrentSet, as the lock is already held.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/set"
+//		"github.com/Jamlie/set/concurrentset"
+//	)
+//
+//	func main() {
+//		inFlight := concurrentset.New[string]()
+//
+//		inFlight.Update(func(tx *set.Set[string]) {
+//			if tx.Len() < 10 && !tx.Contains("job-1") {
+//				tx.Insert("job-1")
+//			}
+//		})
+//	}
+func (s *ConcurrentSet[T]) Update(fn func(tx *set.Set[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(s.own())
+}
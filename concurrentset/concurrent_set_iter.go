@@ -1,6 +1,9 @@
 package concurrentset
 
-import "github.com/Jamlie/set/internal"
+import (
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/internal"
+)
 
 type concurrentSetIter[T comparable] struct {
 	set         *ConcurrentSet[T]
@@ -8,41 +11,39 @@ type concurrentSetIter[T comparable] struct {
 }
 
 func (it *concurrentSetIter[T]) Map(fn internal.MapIterFn[T]) *concurrentSetIter[T] {
-	newSet := New[T]()
+	newSet := set.New[T]()
 
-	it.internalSet.mu.RLock()
-	defer it.internalSet.mu.RUnlock()
+	it.internalSet.mu.Lock()
+	defer it.internalSet.mu.Unlock()
 
-	for k := range it.internalSet.set {
+	for k := range it.internalSet.set.All() {
 		newKey := fn(k)
-		newSet.set[newKey] = struct{}{}
+		newSet.Insert(newKey)
 	}
 
-	it.internalSet.set = newSet.set
+	it.internalSet.replace(newSet)
 	return it.internalSet.Iter()
 }
 
 func (it *concurrentSetIter[T]) Filter(fn internal.FilterIterFn[T]) *concurrentSetIter[T] {
-	newSet := New[T]()
+	newSet := set.New[T]()
 
-	it.internalSet.mu.RLock()
-	defer it.internalSet.mu.RUnlock()
+	it.internalSet.mu.Lock()
+	defer it.internalSet.mu.Unlock()
 
-	for k := range it.internalSet.set {
+	for k := range it.internalSet.set.All() {
 		if fn(k) {
-			newSet.set[k] = struct{}{}
+			newSet.Insert(k)
 		}
 	}
 
-	it.internalSet.set = newSet.set
+	it.internalSet.replace(newSet)
 	return it.internalSet.Iter()
 }
 
+// ForEach iterates over a snapshot of the set, so writers are not blocked while it runs.
 func (it *concurrentSetIter[T]) ForEach(fn internal.ForEachIterFn[T]) {
-	it.internalSet.mu.RLock()
-	defer it.internalSet.mu.RUnlock()
-
-	for k := range it.internalSet.set {
+	for k := range it.internalSet.Snapshot().All() {
 		fn(k)
 	}
 }
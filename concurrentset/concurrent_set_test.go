@@ -0,0 +1,81 @@
+package concurrentset_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/concurrentset"
+)
+
+func TestConcurrentSetInsertIfAbsent(t *testing.T) {
+	s := concurrentset.New[int]()
+
+	var inserted atomic.Int64
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range 100 {
+				if s.InsertIfAbsent(k) {
+					inserted.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if inserted.Load() != 100 || s.Len() != 100 {
+		t.Fatalf("Expected: %d, Got: %d inserts and %d elements", 100, inserted.Load(), s.Len())
+	}
+}
+
+func TestConcurrentSetCompoundOperations(t *testing.T) {
+	s := concurrentset.New[int]()
+
+	tests := []struct {
+		name   string
+		got    any
+		expect any
+	}{
+		{name: "InsertAll", got: s.InsertAll(1, 2, 3, 3), expect: 3},
+		{name: "InsertAll again", got: s.InsertAll(3, 4), expect: 1},
+		{name: "DeleteIfPresent", got: s.DeleteIfPresent(4), expect: true},
+		{name: "DeleteIfPresent missing", got: s.DeleteIfPresent(4), expect: false},
+		{name: "Swap", got: s.Swap(1, 10), expect: true},
+		{name: "Swap missing old", got: s.Swap(1, 11), expect: false},
+		{name: "Swap existing new", got: s.Swap(2, 3), expect: false},
+		{name: "DeleteAll", got: s.DeleteAll(2, 5, 10), expect: 2},
+		{name: "Len", got: s.Len(), expect: 1},
+	}
+
+	for _, test := range tests {
+		if test.got != test.expect {
+			t.Fatalf("%s: Expected: %v, Got: %v", test.name, test.expect, test.got)
+		}
+	}
+}
+
+func TestConcurrentSetUpdate(t *testing.T) {
+	s := concurrentset.New[int]()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				s.Update(func(tx *set.Set[int]) {
+					tx.Insert(tx.Len())
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s.Len() != 800 || !s.Contains(799) {
+		t.Fatalf("Expected: %d, Got: %d", 800, s.Len())
+	}
+}
@@ -0,0 +1,29 @@
+package concurrentset
+
+// fingerprint is the fingerprint of a ConcurrentSet at a version.
+type fingerprint struct {
+	version uint64
+	sum     uint64
+}
+
+// Returns a 64-bit hash of the elements of the set that doesn't depend on their order,
+// the same as `set.Set.Fingerprint` for the same elements.
+//
+// The fingerprint is kept until the next write, so calling Fingerprint on a set that
+// didn't change is cheap, which makes it a way to detect changes.
+func (s *ConcurrentSet[T]) Fingerprint() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if fp := s.fingerprint.Load(); fp != nil && fp.version == s.version {
+		return fp.sum
+	}
+
+	fp := &fingerprint{
+		version: s.version,
+		sum:     s.set.Fingerprint(),
+	}
+	s.fingerprint.Store(fp)
+
+	return fp.sum
+}
@@ -0,0 +1,37 @@
+package concurrentset_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/concurrentset"
+)
+
+func TestFingerprint(t *testing.T) {
+	s := concurrentset.New[int]()
+
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.Insert(i)
+		}()
+		go func() {
+			defer wg.Done()
+			s.Fingerprint()
+		}()
+	}
+	wg.Wait()
+
+	expect := set.FromSlice(s.Keys()).Fingerprint()
+	if got := s.Fingerprint(); got != expect {
+		t.Fatalf("Expected: %x, Got: %x", expect, got)
+	}
+
+	s.Delete(0)
+	if s.Fingerprint() == expect {
+		t.Fatalf("Expected: fingerprint to change, Got: %x", expect)
+	}
+}
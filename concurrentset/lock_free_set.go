@@ -0,0 +1,168 @@
+package concurrentset
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"sync/atomic"
+)
+
+// A `LockFreeSet` is implemented as a copy-on-write `map[T]struct{}` behind an `atomic.Pointer`.
+//
+// Readers load the current map and never block, while every write copies the map and
+// publishes the copy with a compare-and-swap, retrying if another write won the race.
+// Writes are O(n), so LockFreeSet is meant for read-heavy sets that rarely change.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/concurrentset"
+//	)
+//
+//	func main() {
+//		allowed := concurrentset.NewLockFree[string]()
+//		allowed.Insert("GET")
+//		allowed.Insert("HEAD")
+//
+//		if allowed.InsertIfAbsent("POST") {
+//			fmt.Println("POST is now allowed")
+//		}
+//		fmt.Println(allowed.Contains("GET"))
+//	}
+type LockFreeSet[T comparable] struct {
+	set atomic.Pointer[map[T]struct{}]
+}
+
+func NewLockFree[T comparable]() *LockFreeSet[T] {
+	s := &LockFreeSet[T]{}
+	s.set.Store(&map[T]struct{}{})
+	return s
+}
+
+func (s *LockFreeSet[T]) load() map[T]struct{} {
+	if m := s.set.Load(); m != nil {
+		return *m
+	}
+	return nil
+}
+
+// update replaces the map with the result of fn until no other write races with it.
+// fn must not modify the map it receives, and returning false leaves the set unchanged.
+func (s *LockFreeSet[T]) update(fn func(old map[T]struct{}) (map[T]struct{}, bool)) bool {
+	for {
+		old := s.set.Load()
+
+		var m map[T]struct{}
+		if old != nil {
+			m = *old
+		}
+
+		next, changed := fn(m)
+		if !changed {
+			return false
+		}
+
+		if s.set.CompareAndSwap(old, &next) {
+			return true
+		}
+	}
+}
+
+func (s *LockFreeSet[T]) Insert(k T) {
+	s.InsertIfAbsent(k)
+}
+
+// Inserts `k` and returns `true`, or returns `false` if it was already in the set.
+func (s *LockFreeSet[T]) InsertIfAbsent(k T) bool {
+	return s.update(func(old map[T]struct{}) (map[T]struct{}, bool) {
+		if _, ok := old[k]; ok {
+			return nil, false
+		}
+
+		next := make(map[T]struct{}, len(old)+1)
+		maps.Copy(next, old)
+		next[k] = struct{}{}
+		return next, true
+	})
+}
+
+func (s *LockFreeSet[T]) Delete(k T) {
+	s.DeleteIfPresent(k)
+}
+
+// Deletes `k` and returns `true`, or returns `false` if it was not in the set.
+func (s *LockFreeSet[T]) DeleteIfPresent(k T) bool {
+	return s.update(func(old map[T]struct{}) (map[T]struct{}, bool) {
+		if _, ok := old[k]; !ok {
+			return nil, false
+		}
+
+		next := maps.Clone(old)
+		delete(next, k)
+		return next, true
+	})
+}
+
+func (s *LockFreeSet[T]) Len() int {
+	return len(s.load())
+}
+
+func (s *LockFreeSet[T]) Contains(k T) bool {
+	_, ok := s.load()[k]
+	return ok
+}
+
+func (s *LockFreeSet[T]) Clone() *LockFreeSet[T] {
+	clone := &LockFreeSet[T]{}
+	m := s.load()
+	if m == nil {
+		m = map[T]struct{}{}
+	}
+	clone.set.Store(&m)
+	return clone
+}
+
+func (s *LockFreeSet[T]) Keys() []T {
+	m := s.load()
+	keys := make([]T, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func (s *LockFreeSet[T]) Clear() {
+	s.set.Store(&map[T]struct{}{})
+}
+
+func (s *LockFreeSet[T]) Empty() bool {
+	return s.Len() == 0
+}
+
+func (s *LockFreeSet[T]) String() string {
+	return fmt.Sprint(s.Keys())
+}
+
+// Iterates over the set as it was when All was called, writes made while
+// iterating are not visible.
+func (s *LockFreeSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s.load() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func (s *LockFreeSet[T]) Iter() *lockFreeSetIter[T] {
+	return &lockFreeSetIter[T]{
+		set: s,
+	}
+}
@@ -0,0 +1,42 @@
+package concurrentset
+
+import "github.com/Jamlie/set/internal"
+
+type lockFreeSetIter[T comparable] struct {
+	set *LockFreeSet[T]
+}
+
+func (it *lockFreeSetIter[T]) Map(fn internal.MapIterFn[T]) *lockFreeSetIter[T] {
+	it.set.update(func(old map[T]struct{}) (map[T]struct{}, bool) {
+		next := make(map[T]struct{}, len(old))
+		for k := range old {
+			next[fn(k)] = struct{}{}
+		}
+		return next, true
+	})
+
+	return it.set.Iter()
+}
+
+func (it *lockFreeSetIter[T]) Filter(fn internal.FilterIterFn[T]) *lockFreeSetIter[T] {
+	it.set.update(func(old map[T]struct{}) (map[T]struct{}, bool) {
+		next := make(map[T]struct{}, len(old))
+		for k := range old {
+			if fn(k) {
+				next[k] = struct{}{}
+			}
+		}
+		return next, true
+	})
+
+	return it.set.Iter()
+}
+
+func (it *lockFreeSetIter[T]) ForEach(fn internal.ForEachIterFn[T]) {
+	for k := range it.set.All() {
+		fn(k)
+	}
+}
+
+// Map and Filter already update the set, Collect is kept for parity with ConcurrentSet.
+func (it *lockFreeSetIter[T]) Collect() {}
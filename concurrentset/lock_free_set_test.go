@@ -0,0 +1,78 @@
+package concurrentset_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Jamlie/set/concurrentset"
+)
+
+func TestLockFreeSetInsertIfAbsent(t *testing.T) {
+	s := concurrentset.NewLockFree[int]()
+
+	var inserted, deleted atomic.Int64
+	run := func(fn func()) {
+		var wg sync.WaitGroup
+		for range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				fn()
+			}()
+		}
+		wg.Wait()
+	}
+
+	run(func() {
+		for k := range 200 {
+			if s.InsertIfAbsent(k) {
+				inserted.Add(1)
+			}
+			for range s.All() {
+			}
+		}
+	})
+
+	run(func() {
+		for k := range 100 {
+			if s.DeleteIfPresent(k) {
+				deleted.Add(1)
+			}
+		}
+	})
+
+	if inserted.Load() != 200 || deleted.Load() != 100 {
+		t.Fatalf("Expected: 200 inserts and 100 deletes, Got: %d and %d", inserted.Load(), deleted.Load())
+	}
+
+	if s.Len() != 100 || s.Contains(0) || !s.Contains(100) {
+		t.Fatalf("Expected: %d elements, Got: %d", 100, s.Len())
+	}
+}
+
+func TestLockFreeSetSnapshotIteration(t *testing.T) {
+	s := concurrentset.NewLockFree[int]()
+	s.Insert(1)
+	s.Insert(2)
+
+	n := 0
+	for k := range s.All() {
+		s.Insert(k + 10)
+		n++
+	}
+
+	if n != 2 || s.Len() != 4 {
+		t.Fatalf("Expected: 2 visited and 4 elements, Got: %d and %d", n, s.Len())
+	}
+
+	s.Iter().
+		Filter(func(k int) bool {
+			return k > 10
+		}).
+		Collect()
+
+	if s.Len() != 2 || !s.Contains(11) {
+		t.Fatalf("Expected: %v, Got: %s", []int{11, 12}, s)
+	}
+}
@@ -0,0 +1,146 @@
+package concurrentset
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Jamlie/set"
+)
+
+// Calls `fn` for every element of a snapshot of the set, using `workers` goroutines.
+//
+// The elements are handed to the workers one at a time, and no lock is held while `fn`
+// runs, so `fn` may modify the set. The first error returned by `fn` cancels the context
+// passed to the other calls and no new element is started. Every error returned by `fn`,
+// as well as the cause of the cancellation of `ctx`, is joined into the returned error.
+// This method will panic if workers is not positive.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"context"
+//		"log"
+//
+//		"github.com/Jamlie/set/concurrentset"
+//	)
+//
+//	func main() {
+//		hosts := concurrentset.New[string]()
+//		hosts.InsertAll("a.example.com", "b.example.com")
+//
+//		err := hosts.ParallelForEach(context.Background(), 8, func(ctx context.Context, host string) error {
+//			return ping(ctx, host)
+//		})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func (s *ConcurrentSet[T]) ParallelForEach(ctx context.Context, workers int, fn func(context.Context, T) error) error {
+	keys := s.Keys()
+
+	return parallel(ctx, workers, len(keys), func(ctx context.Context, i int) error {
+		return fn(ctx, keys[i])
+	})
+}
+
+// Returns a new set with the result of calling `fn` on every element of a snapshot of
+// the set, using `workers` goroutines. Unlike Iter().Map, the set itself is not modified:
+// the result is a copy, so writes made while `fn` runs are never lost.
+//
+// Errors and cancellation are handled as in ParallelForEach, and no set is returned
+// if any of them happens.
+func (s *ConcurrentSet[T]) ParallelMapped(ctx context.Context, workers int, fn func(context.Context, T) (T, error)) (*set.Set[T], error) {
+	keys := s.Keys()
+	mapped := make([]T, len(keys))
+
+	err := parallel(ctx, workers, len(keys), func(ctx context.Context, i int) error {
+		var err error
+		mapped[i], err = fn(ctx, keys[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return set.FromSlice(mapped), nil
+}
+
+// Returns a new set with the elements of a snapshot of the set for which `fn` returns
+// `true`, using `workers` goroutines. Unlike Iter().Filter, the set itself is not
+// modified: the result is a copy, so writes made while `fn` runs are never lost.
+//
+// Errors and cancellation are handled as in ParallelForEach, and no set is returned
+// if any of them happens.
+func (s *ConcurrentSet[T]) ParallelFiltered(ctx context.Context, workers int, fn func(context.Context, T) (bool, error)) (*set.Set[T], error) {
+	keys := s.Keys()
+	keep := make([]bool, len(keys))
+
+	err := parallel(ctx, workers, len(keys), func(ctx context.Context, i int) error {
+		var err error
+		keep[i], err = fn(ctx, keys[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := set.New[T]()
+	for i, k := range keys {
+		if keep[i] {
+			result.Insert(k)
+		}
+	}
+
+	return result, nil
+}
+
+// parallel calls fn for every index in [0, n) using up to `workers` goroutines,
+// stopping at the first error or when ctx is done.
+func parallel(ctx context.Context, workers, n int, fn func(context.Context, int) error) error {
+	if workers <= 0 {
+		panic("Cannot run with a non-positive number of workers")
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		next atomic.Int64
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+	)
+
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil {
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return
+				}
+
+				if err := fn(ctx, i); err != nil {
+					mu.Lock()
+					errs = append(errs, err)
+					mu.Unlock()
+					cancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if parent.Err() != nil {
+		errs = append(errs, context.Cause(parent))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,89 @@
+package concurrentset_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Jamlie/set/concurrentset"
+)
+
+func TestParallelForEach(t *testing.T) {
+	s := concurrentset.New[int]()
+	for k := range 1000 {
+		s.Insert(k)
+	}
+
+	var sum atomic.Int64
+	err := s.ParallelForEach(context.Background(), 8, func(_ context.Context, k int) error {
+		sum.Add(int64(k))
+		s.Delete(k) // must not deadlock
+		return nil
+	})
+
+	if err != nil || sum.Load() != 999*1000/2 || !s.Empty() {
+		t.Fatalf("Expected: sum %d and no error, Got: %d, %v", 999*1000/2, sum.Load(), err)
+	}
+}
+
+func TestParallelForEachStopsOnError(t *testing.T) {
+	s := concurrentset.New[int]()
+	for k := range 1000 {
+		s.Insert(k)
+	}
+
+	errBoom := errors.New("boom")
+	var calls atomic.Int64
+	err := s.ParallelForEach(context.Background(), 1, func(ctx context.Context, k int) error {
+		if calls.Add(1) == 10 {
+			return errBoom
+		}
+		return nil
+	})
+
+	if !errors.Is(err, errBoom) || calls.Load() != 10 {
+		t.Fatalf("Expected: %v after 10 calls, Got: %v after %d calls", errBoom, err, calls.Load())
+	}
+}
+
+func TestParallelForEachCanceled(t *testing.T) {
+	s := concurrentset.New[int]()
+	s.InsertAll(1, 2, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := s.ParallelForEach(ctx, 4, func(context.Context, int) error {
+		return nil
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected: %v, Got: %v", context.Canceled, err)
+	}
+}
+
+func TestParallelMappedFiltered(t *testing.T) {
+	s := concurrentset.New[int]()
+	for k := range 10 {
+		s.Insert(k)
+	}
+
+	mapped, err := s.ParallelMapped(context.Background(), 4, func(_ context.Context, k int) (int, error) {
+		return k / 2, nil
+	})
+	if err != nil || mapped.Len() != 5 {
+		t.Fatalf("Expected: 5 elements, Got: %v, %v", mapped, err)
+	}
+
+	filtered, err := s.ParallelFiltered(context.Background(), 4, func(_ context.Context, k int) (bool, error) {
+		return k%3 == 0, nil
+	})
+	if err != nil || filtered.Len() != 4 || !filtered.Contains(9) {
+		t.Fatalf("Expected: %v, Got: %v, %v", []int{0, 3, 6, 9}, filtered, err)
+	}
+
+	if s.Len() != 10 {
+		t.Fatalf("Expected: the set to be unchanged, Got: %s", s)
+	}
+}
@@ -0,0 +1,177 @@
+package concurrentset
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"runtime"
+)
+
+// A `ShardedSet` is implemented as a slice of ConcurrentSets, called shards.
+//
+// Every element belongs to the shard picked by its `hash/maphash` hash, so writes to
+// elements of different shards don't wait for each other. It has the same methods as
+// ConcurrentSet, and operations touching the whole set, like Len or Keys, visit the
+// shards one at a time, so they are not atomic with respect to concurrent writes.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"sync"
+//
+//		"github.com/Jamlie/set/concurrentset"
+//	)
+//
+//	func main() {
+//		seen := concurrentset.NewSharded[int]()
+//
+//		var wg sync.WaitGroup
+//		for i := range 64 {
+//			wg.Add(1)
+//			go func() {
+//				defer wg.Done()
+//				seen.Insert(i % 10)
+//			}()
+//		}
+//		wg.Wait()
+//
+//		fmt.Println(seen.Len()) // 10
+//	}
+type ShardedSet[T comparable] struct {
+	shards []*ConcurrentSet[T]
+	seed   maphash.Seed
+}
+
+// Creates a ShardedSet with a number of shards based on GOMAXPROCS.
+func NewSharded[T comparable]() *ShardedSet[T] {
+	return WithShards[T](defaultShards())
+}
+
+// Creates a ShardedSet with `shards` shards. This function will panic if shards is not positive.
+func WithShards[T comparable](shards int) *ShardedSet[T] {
+	if shards <= 0 {
+		panic("Cannot allocate with a non-positive number of shards")
+	}
+
+	s := &ShardedSet[T]{
+		shards: make([]*ConcurrentSet[T], shards),
+		seed:   maphash.MakeSeed(),
+	}
+	for i := range s.shards {
+		s.shards[i] = New[T]()
+	}
+
+	return s
+}
+
+func defaultShards() int {
+	return 1 << bits.Len(uint(4*runtime.GOMAXPROCS(0)-1))
+}
+
+func (s *ShardedSet[T]) index(k T) int {
+	return int(maphash.Comparable(s.seed, k) % uint64(len(s.shards)))
+}
+
+func (s *ShardedSet[T]) shard(k T) *ConcurrentSet[T] {
+	return s.shards[s.index(k)]
+}
+
+func (s *ShardedSet[T]) Insert(k T) {
+	s.shard(k).Insert(k)
+}
+
+func (s *ShardedSet[T]) Delete(k T) {
+	s.shard(k).Delete(k)
+}
+
+func (s *ShardedSet[T]) Contains(k T) bool {
+	return s.shard(k).Contains(k)
+}
+
+// Returns the sum of the lengths of the shards.
+func (s *ShardedSet[T]) Len() int {
+	n := 0
+	for _, shard := range s.shards {
+		n += shard.Len()
+	}
+	return n
+}
+
+func (s *ShardedSet[T]) Clone() *ShardedSet[T] {
+	clone := &ShardedSet[T]{
+		shards: make([]*ConcurrentSet[T], len(s.shards)),
+		seed:   s.seed,
+	}
+	for i, shard := range s.shards {
+		clone.shards[i] = shard.Clone()
+	}
+
+	return clone
+}
+
+func (s *ShardedSet[T]) Keys() []T {
+	keys := make([]T, 0, s.Len())
+	for _, shard := range s.shards {
+		keys = append(keys, shard.Keys()...)
+	}
+
+	return keys
+}
+
+func (s *ShardedSet[T]) Clear() {
+	for _, shard := range s.shards {
+		shard.Clear()
+	}
+}
+
+func (s *ShardedSet[T]) Empty() bool {
+	for _, shard := range s.shards {
+		if !shard.Empty() {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ShardedSet[T]) String() string {
+	return fmt.Sprint(s.Keys())
+}
+
+func (s *ShardedSet[T]) NumShards() int {
+	return len(s.shards)
+}
+
+// Yields the index and a copy of the keys of every shard, taken while holding that
+// shard's read lock, so the set can be modified while iterating.
+func (s *ShardedSet[T]) Shards() iter.Seq2[int, []T] {
+	return func(yield func(int, []T) bool) {
+		for i, shard := range s.shards {
+			if !yield(i, shard.Keys()) {
+				return
+			}
+		}
+	}
+}
+
+// Iterates over the set one shard at a time, see Shards.
+func (s *ShardedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, keys := range s.Shards() {
+			for _, k := range keys {
+				if !yield(k) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *ShardedSet[T]) Iter() *shardedSetIter[T] {
+	return &shardedSetIter[T]{
+		set: s,
+	}
+}
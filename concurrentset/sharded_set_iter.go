@@ -0,0 +1,66 @@
+package concurrentset
+
+import (
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/internal"
+)
+
+type shardedSetIter[T comparable] struct {
+	set *ShardedSet[T]
+}
+
+// Map holds the lock of every shard while it runs, as a value may move to another shard,
+// so `fn` must not call any method of the set.
+func (it *shardedSetIter[T]) Map(fn internal.MapIterFn[T]) *shardedSetIter[T] {
+	shards := it.set.shards
+	for _, shard := range shards {
+		shard.mu.Lock()
+	}
+	defer func() {
+		for _, shard := range shards {
+			shard.mu.Unlock()
+		}
+	}()
+
+	mapped := make([]*set.Set[T], len(shards))
+	for i := range mapped {
+		mapped[i] = set.New[T]()
+	}
+
+	for _, shard := range shards {
+		for k := range shard.set.All() {
+			newKey := fn(k)
+			mapped[it.set.index(newKey)].Insert(newKey)
+		}
+	}
+
+	for i, shard := range shards {
+		shard.replace(mapped[i])
+	}
+
+	return it.set.Iter()
+}
+
+func (it *shardedSetIter[T]) Filter(fn internal.FilterIterFn[T]) *shardedSetIter[T] {
+	for _, shard := range it.set.shards {
+		shard.mu.Lock()
+		tx := shard.own()
+		for k := range tx.All() {
+			if !fn(k) {
+				tx.Delete(k)
+			}
+		}
+		shard.mu.Unlock()
+	}
+
+	return it.set.Iter()
+}
+
+func (it *shardedSetIter[T]) ForEach(fn internal.ForEachIterFn[T]) {
+	for k := range it.set.All() {
+		fn(k)
+	}
+}
+
+// Map and Filter already update the set, Collect is kept for parity with ConcurrentSet.
+func (it *shardedSetIter[T]) Collect() {}
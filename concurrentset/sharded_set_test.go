@@ -0,0 +1,145 @@
+package concurrentset_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Jamlie/set/concurrentset"
+)
+
+func TestShardedSetConcurrentInsert(t *testing.T) {
+	s := concurrentset.WithShards[int](8)
+
+	var wg sync.WaitGroup
+	for g := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range 1000 {
+				s.Insert(k)
+				if k%2 == 1 && g%2 == 0 {
+					s.Delete(k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for k := range 1000 {
+		s.Delete(k)
+		s.Insert(k)
+	}
+
+	if s.Len() != 1000 || len(s.Keys()) != 1000 {
+		t.Fatalf("Expected: %d, Got: %d", 1000, s.Len())
+	}
+
+	n := 0
+	for _, keys := range s.Shards() {
+		n += len(keys)
+	}
+	if n != 1000 {
+		t.Fatalf("Expected: %d, Got: %d", 1000, n)
+	}
+}
+
+func TestShardedSetIter(t *testing.T) {
+	s := concurrentset.WithShards[int](4)
+	for k := range 10 {
+		s.Insert(k)
+	}
+
+	s.Iter().
+		Filter(func(k int) bool {
+			return k%2 == 0
+		}).
+		Map(func(k int) int {
+			return k * 10
+		}).
+		Collect()
+
+	for _, k := range []int{0, 20, 40, 60, 80} {
+		if !s.Contains(k) {
+			t.Fatalf("Expected: %v, Got: %s", []int{0, 20, 40, 60, 80}, s)
+		}
+	}
+
+	if s.Len() != 5 {
+		t.Fatalf("Expected: %d, Got: %d", 5, s.Len())
+	}
+}
+
+func TestShardedSetMapConcurrentInsert(t *testing.T) {
+	s := concurrentset.WithShards[int](4)
+	for k := range 10 {
+		s.Insert(k)
+	}
+
+	started := make(chan struct{})
+	inserted := make(chan struct{})
+	go func() {
+		<-started
+		s.Insert(100)
+		close(inserted)
+	}()
+
+	var once sync.Once
+	s.Iter().Map(func(k int) int {
+		once.Do(func() {
+			close(started)
+			// give the insert time to run while Map is still mapping
+			time.Sleep(10 * time.Millisecond)
+		})
+		return k + 10
+	})
+	<-inserted
+
+	if !s.Contains(100) || s.Len() != 11 {
+		t.Fatalf("Expected: 100 inserted during Map to be kept, Got: %s", s)
+	}
+}
+
+type benchSet interface {
+	Insert(k int)
+	Delete(k int)
+	Contains(k int) bool
+}
+
+// benchmarkMixed runs a workload of 90% reads, 5% inserts and 5% deletes.
+func benchmarkMixed(b *testing.B, s benchSet) {
+	const keys = 1 << 16
+	for k := range keys / 2 {
+		s.Insert(k)
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+		for pb.Next() {
+			k := r.IntN(keys)
+			switch op := r.IntN(100); {
+			case op < 5:
+				s.Insert(k)
+			case op < 10:
+				s.Delete(k)
+			default:
+				s.Contains(k)
+			}
+		}
+	})
+}
+
+func BenchmarkConcurrentSetMixed(b *testing.B) {
+	benchmarkMixed(b, concurrentset.New[int]())
+}
+
+func BenchmarkShardedSetMixed(b *testing.B) {
+	for _, shards := range []int{1, 8, 64} {
+		b.Run(fmt.Sprintf("shards=%d", shards), func(b *testing.B) {
+			benchmarkMixed(b, concurrentset.WithShards[int](shards))
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package concurrentset
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/internal"
+)
+
+// A `Snapshot` is a read-only, point-in-time view of a ConcurrentSet.
+//
+// Taking a snapshot doesn't copy the set. Instead, the next write to the ConcurrentSet
+// copies it, so the snapshot keeps seeing the elements it was taken with. Snapshots can
+// be iterated and combined for as long as needed without blocking writers, and can be
+// read from many goroutines without any locking.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/concurrentset"
+//	)
+//
+//	func main() {
+//		v := concurrentset.New[int]()
+//		v.Insert(1)
+//		v.Insert(2)
+//
+//		snap := v.Snapshot()
+//		v.Insert(3) // copies the set, snap is not affected
+//
+//		for k := range snap.All() {
+//			v.Delete(k) // doesn't block
+//		}
+//		fmt.Println(snap.Len(), v.Len()) // 2 1
+//	}
+type Snapshot[T comparable] struct {
+	set *set.Set[T]
+}
+
+func (s *ConcurrentSet[T]) Snapshot() *Snapshot[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	s.shared.Store(true)
+	return &Snapshot[T]{
+		set: s.set,
+	}
+}
+
+// Iterates over a snapshot of the set, see Snapshot.
+func (s *ConcurrentSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s.Snapshot().All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Snapshot[T]) Len() int {
+	return s.set.Len()
+}
+
+func (s *Snapshot[T]) Contains(k T) bool {
+	return s.set.Contains(k)
+}
+
+func (s *Snapshot[T]) Empty() bool {
+	return s.set.Empty()
+}
+
+func (s *Snapshot[T]) Keys() []T {
+	return s.set.Keys()
+}
+
+func (s *Snapshot[T]) All() iter.Seq[T] {
+	return s.set.All()
+}
+
+func (s *Snapshot[T]) String() string {
+	return fmt.Sprint(s.Keys())
+}
+
+// Returns a mutable copy of the snapshot.
+func (s *Snapshot[T]) Thaw() *set.Set[T] {
+	return s.set.Clone()
+}
+
+func (s *Snapshot[T]) Union(other *Snapshot[T]) *set.Set[T] {
+	return collect(internal.Union[T](s, other))
+}
+
+func (s *Snapshot[T]) Intersection(other *Snapshot[T]) *set.Set[T] {
+	return collect(internal.Intersection[T](s, other))
+}
+
+func (s *Snapshot[T]) Difference(other *Snapshot[T]) *set.Set[T] {
+	return collect(internal.Difference[T](s, other))
+}
+
+func (s *Snapshot[T]) SymmetricDifference(other *Snapshot[T]) *set.Set[T] {
+	return collect(internal.SymmetricDifference[T](s, other))
+}
+
+func (s *Snapshot[T]) IsSubset(other *Snapshot[T]) bool {
+	return internal.IsSubset[T](s, other)
+}
+
+func (s *Snapshot[T]) Equal(other *Snapshot[T]) bool {
+	return internal.Equal[T](s, other)
+}
+
+func collect[T comparable](seq iter.Seq[T]) *set.Set[T] {
+	result := set.New[T]()
+	result.InsertSeq(seq)
+	return result
+}
@@ -0,0 +1,104 @@
+package concurrentset_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/concurrentset"
+)
+
+func TestSnapshotIsPointInTime(t *testing.T) {
+	s := concurrentset.New[int]()
+	s.InsertAll(1, 2, 3)
+
+	snap := s.Snapshot()
+
+	s.Insert(4)
+	s.Delete(1)
+	s.Update(func(tx *set.Set[int]) {
+		tx.Insert(5)
+	})
+
+	if snap.Len() != 3 || !snap.Contains(1) || snap.Contains(4) {
+		t.Fatalf("Expected: %v, Got: %s", []int{1, 2, 3}, snap)
+	}
+
+	if s.Len() != 4 || s.Contains(1) {
+		t.Fatalf("Expected: %v, Got: %s", []int{2, 3, 4, 5}, s)
+	}
+
+	s.Clear()
+	if snap.Len() != 3 {
+		t.Fatalf("Expected: %v, Got: %s", []int{1, 2, 3}, snap)
+	}
+}
+
+func TestSnapshotDoesNotBlockWriters(t *testing.T) {
+	s := concurrentset.New[int]()
+	for k := range 100 {
+		s.Insert(k)
+	}
+
+	done := make(chan struct{})
+	for k := range s.All() {
+		if k == 0 {
+			go func() {
+				defer close(done)
+				s.Insert(1000)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatalf("Expected: writer to finish while iterating")
+			}
+		}
+	}
+
+	if !s.Contains(1000) {
+		t.Fatalf("Expected: %d to be inserted", 1000)
+	}
+}
+
+func TestSnapshotAlgebra(t *testing.T) {
+	a := concurrentset.New[int]()
+	a.InsertAll(1, 2, 3, 4)
+	b := concurrentset.New[int]()
+	b.InsertAll(3, 4, 5)
+
+	sa, sb := a.Snapshot(), b.Snapshot()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for k := range 100 {
+			a.Insert(k)
+			b.Delete(k)
+		}
+	}()
+
+	tests := []struct {
+		name   string
+		result *set.Set[int]
+		expect int
+	}{
+		{name: "union", result: sa.Union(sb), expect: 5},
+		{name: "intersection", result: sa.Intersection(sb), expect: 2},
+		{name: "difference", result: sa.Difference(sb), expect: 2},
+		{name: "symmetric difference", result: sa.SymmetricDifference(sb), expect: 3},
+	}
+	wg.Wait()
+
+	for _, test := range tests {
+		if test.result.Len() != test.expect {
+			t.Fatalf("%s: Expected: %d elements, Got: %s", test.name, test.expect, test.result)
+		}
+	}
+
+	if sa.Equal(a.Snapshot()) || !sb.IsSubset(sb) {
+		t.Fatalf("Expected: snapshots to keep their elements")
+	}
+}
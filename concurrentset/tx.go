@@ -0,0 +1,218 @@
+package concurrentset
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/Jamlie/set"
+)
+
+// ErrConflict is returned by Tx.Commit when another write changed something the
+// transaction read or wrote since it began.
+var ErrConflict = errors.New("concurrentset: transaction conflicts with a concurrent write")
+
+// A `Tx` records inserts and deletes to a ConcurrentSet and applies them atomically on Commit.
+//
+// Reads see the transaction's own writes on top of the set. Contains reads the live set and
+// remembers what it saw, so reading an element again gives the same answer, while Len and
+// All take a Snapshot the first time they are called and read from it afterwards. Commit
+// validates optimistically that no element the transaction read or wrote has changed since
+// it was first seen, and that the set was not written at all after the snapshot was taken.
+// If validation fails nothing is applied and ErrConflict is returned, so two transactions
+// touching the same element never both succeed. A Tx is not safe for concurrent use, but
+// any number of transactions may run concurrently.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"errors"
+//
+//		"github.com/Jamlie/set/concurrentset"
+//	)
+//
+//	func main() {
+//		seats := concurrentset.New[int]()
+//
+//		for {
+//			tx := seats.Begin()
+//			if tx.Contains(12) || tx.Contains(13) {
+//				tx.Rollback()
+//				break
+//			}
+//			tx.Insert(12)
+//			tx.Insert(13)
+//
+//			if err := tx.Commit(); !errors.Is(err, concurrentset.ErrConflict) {
+//				break
+//			}
+//		}
+//	}
+type Tx[T comparable] struct {
+	set     *ConcurrentSet[T]
+	version uint64
+	// snap is taken by the first Len or All, at snapVersion.
+	snap        *set.Set[T]
+	snapVersion uint64
+	// changes maps every written key to whether it's in the set after the transaction.
+	changes map[T]bool
+	// seen maps every key read or written to whether it was in the set when first seen.
+	seen map[T]bool
+	done bool
+}
+
+func (s *ConcurrentSet[T]) Begin() *Tx[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return &Tx[T]{
+		set:     s,
+		version: s.version,
+		changes: make(map[T]bool),
+		seen:    make(map[T]bool),
+	}
+}
+
+// observe returns whether `k` was in the set when the transaction first saw it.
+func (tx *Tx[T]) observe(k T) bool {
+	if in, ok := tx.seen[k]; ok {
+		return in
+	}
+
+	var in bool
+	if tx.snap != nil {
+		in = tx.snap.Contains(k)
+	} else {
+		in = tx.set.Contains(k)
+	}
+
+	tx.seen[k] = in
+	return in
+}
+
+// snapshot returns the snapshot read by Len and All, taking it on the first call.
+func (tx *Tx[T]) snapshot() *set.Set[T] {
+	if tx.snap == nil {
+		s := tx.set
+		s.mu.RLock()
+		s.shared.Store(true)
+		tx.snap, tx.snapVersion = s.set, s.version
+		s.mu.RUnlock()
+	}
+
+	return tx.snap
+}
+
+func (tx *Tx[T]) write(k T, in bool) {
+	if tx.done {
+		panic("Cannot write to a transaction that has already been committed or rolled back")
+	}
+
+	tx.observe(k)
+	tx.changes[k] = in
+}
+
+func (tx *Tx[T]) Insert(k T) {
+	tx.write(k, true)
+}
+
+func (tx *Tx[T]) Delete(k T) {
+	tx.write(k, false)
+}
+
+func (tx *Tx[T]) Contains(k T) bool {
+	if in, ok := tx.changes[k]; ok {
+		return in
+	}
+
+	return tx.observe(k)
+}
+
+func (tx *Tx[T]) Len() int {
+	snap := tx.snapshot()
+
+	n := snap.Len()
+	for k, in := range tx.changes {
+		switch has := snap.Contains(k); {
+		case in && !has:
+			n++
+		case !in && has:
+			n--
+		}
+	}
+
+	return n
+}
+
+func (tx *Tx[T]) All() iter.Seq[T] {
+	snap := tx.snapshot()
+
+	return func(yield func(T) bool) {
+		for k := range snap.All() {
+			if in, ok := tx.changes[k]; ok && !in {
+				continue
+			}
+			if !yield(k) {
+				return
+			}
+		}
+
+		for k, in := range tx.changes {
+			if in && !snap.Contains(k) && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Applies the transaction's writes atomically, or returns ErrConflict and applies
+// nothing if a concurrent write invalidated what the transaction read or wrote.
+func (tx *Tx[T]) Commit() error {
+	if tx.done {
+		return set.ErrTxDone
+	}
+	tx.done = true
+
+	s := tx.set
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tx.snap != nil && s.version != tx.snapVersion {
+		return ErrConflict
+	}
+
+	if s.version != tx.version {
+		for k, in := range tx.seen {
+			if s.set.Contains(k) != in {
+				return ErrConflict
+			}
+		}
+	}
+
+	if len(tx.changes) == 0 {
+		return nil
+	}
+
+	current := s.own()
+	for k, in := range tx.changes {
+		if in {
+			current.Insert(k)
+		} else {
+			current.Delete(k)
+		}
+	}
+
+	return nil
+}
+
+// Discards the transaction's writes.
+func (tx *Tx[T]) Rollback() error {
+	if tx.done {
+		return set.ErrTxDone
+	}
+	tx.done = true
+
+	clear(tx.changes)
+	return nil
+}
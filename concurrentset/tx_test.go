@@ -0,0 +1,115 @@
+package concurrentset_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/concurrentset"
+)
+
+func TestTxConflict(t *testing.T) {
+	s := concurrentset.New[int]()
+	s.InsertAll(1, 2)
+
+	a, b := s.Begin(), s.Begin()
+	a.Insert(3)
+	b.Insert(3)
+
+	c := s.Begin()
+	c.Delete(1)
+
+	if err := a.Commit(); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+	if err := b.Commit(); !errors.Is(err, concurrentset.ErrConflict) {
+		t.Fatalf("Expected: %v, Got: %v", concurrentset.ErrConflict, err)
+	}
+	if err := c.Commit(); err != nil {
+		t.Fatalf("Expected: no error for unrelated keys, Got: %v", err)
+	}
+
+	d := s.Begin()
+	if d.Len() != 2 || d.Contains(1) {
+		t.Fatalf("Expected: the transaction to see committed writes, Got: %d", d.Len())
+	}
+	s.Insert(10)
+	if err := d.Commit(); !errors.Is(err, concurrentset.ErrConflict) {
+		t.Fatalf("Expected: %v after reading the whole set, Got: %v", concurrentset.ErrConflict, err)
+	}
+
+	if err := d.Rollback(); !errors.Is(err, set.ErrTxDone) {
+		t.Fatalf("Expected: %v, Got: %v", set.ErrTxDone, err)
+	}
+
+	// writes that change nothing don't conflict with a transaction that read the whole set
+	e := s.Begin()
+	e.Len()
+	e.Insert(20)
+	s.Insert(2)
+	s.InsertAll(2, 3)
+	s.DeleteAll(1, 30)
+	s.Delete(40)
+	if err := e.Commit(); err != nil {
+		t.Fatalf("Expected: no error after no-op writes, Got: %v", err)
+	}
+}
+
+func TestTxIsolation(t *testing.T) {
+	s := concurrentset.New[int]()
+	s.InsertAll(1, 2)
+
+	tx := s.Begin()
+	tx.Insert(3)
+	s.Insert(5)
+
+	if tx.Contains(4) || !tx.Contains(3) || !tx.Contains(5) || s.Contains(3) {
+		t.Fatalf("Expected: writes to stay isolated and reads to see the live set")
+	}
+	s.Insert(4)
+
+	// tx read 4 before it was inserted
+	if err := tx.Commit(); !errors.Is(err, concurrentset.ErrConflict) || s.Contains(3) {
+		t.Fatalf("Expected: %v, Got: %v", concurrentset.ErrConflict, err)
+	}
+
+	tx = s.Begin()
+	tx.Insert(3)
+	s.Delete(5)
+	s.Insert(5)
+
+	if err := tx.Commit(); err != nil || s.Len() != 5 {
+		t.Fatalf("Expected: %v, Got: %s, %v", []int{1, 2, 3, 4, 5}, s, err)
+	}
+}
+
+func TestTxConcurrentClaims(t *testing.T) {
+	s := concurrentset.New[int]()
+
+	var succeeded atomic.Int64
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			tx := s.Begin()
+			if tx.Contains(1) {
+				tx.Rollback()
+				return
+			}
+			tx.Insert(1)
+
+			if tx.Commit() == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded.Load() != 1 {
+		t.Fatalf("Expected: exactly 1 successful claim, Got: %d", succeeded.Load())
+	}
+}
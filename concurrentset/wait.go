@@ -0,0 +1,104 @@
+package concurrentset
+
+import "context"
+
+// Blocks until the set is empty or `ctx` is done, in which case the cause of the
+// cancellation is returned.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"context"
+//		"log"
+//		"time"
+//
+//		"github.com/Jamlie/set/concurrentset"
+//	)
+//
+//	func main() {
+//		inFlight := concurrentset.New[string]()
+//		inFlight.Insert("job-1")
+//
+//		go func() {
+//			time.Sleep(time.Second)
+//			inFlight.Delete("job-1")
+//		}()
+//
+//		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+//		defer cancel()
+//		if err := inFlight.WaitUntilEmpty(ctx); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func (s *ConcurrentSet[T]) WaitUntilEmpty(ctx context.Context) error {
+	return s.wait(ctx, func() bool {
+		return s.set.Empty()
+	})
+}
+
+// Blocks until `k` is in the set or `ctx` is done.
+func (s *ConcurrentSet[T]) WaitFor(ctx context.Context, k T) error {
+	return s.wait(ctx, func() bool {
+		return s.set.Contains(k)
+	})
+}
+
+// Blocks until `k` is not in the set or `ctx` is done.
+func (s *ConcurrentSet[T]) WaitAbsent(ctx context.Context, k T) error {
+	return s.wait(ctx, func() bool {
+		return !s.set.Contains(k)
+	})
+}
+
+// Blocks until the set has an element, then removes it and returns it. Which element is
+// taken is unspecified, and every element is taken by a single caller.
+//
+// If `ctx` is done first, nothing is removed and the cause of the cancellation is returned.
+func (s *ConcurrentSet[T]) TakeAny(ctx context.Context) (T, error) {
+	var taken T
+
+	err := s.wait(ctx, func() bool {
+		for k := range s.set.All() {
+			s.own().Delete(k)
+			taken = k
+			return true
+		}
+		return false
+	})
+
+	return taken, err
+}
+
+// wait calls ready with the write lock held every time the set changes, until it
+// returns true or ctx is done.
+func (s *ConcurrentSet[T]) wait(ctx context.Context, ready func() bool) error {
+	for {
+		s.mu.Lock()
+		if ready() {
+			s.mu.Unlock()
+			return nil
+		}
+
+		if s.changed == nil {
+			s.changed = make(chan struct{})
+		}
+		changed := s.changed
+		s.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// notify wakes up every waiter, it must be called with the write lock held.
+func (s *ConcurrentSet[T]) notify() {
+	if s.changed != nil {
+		close(s.changed)
+		s.changed = nil
+	}
+}
@@ -0,0 +1,135 @@
+package concurrentset_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Jamlie/set/concurrentset"
+)
+
+// checkNoLeaks fails the test if goroutines started by it are still running when it ends.
+func checkNoLeaks(t *testing.T) {
+	t.Helper()
+
+	before := runtime.NumGoroutine()
+	t.Cleanup(func() {
+		deadline := time.Now().Add(time.Second)
+		for runtime.NumGoroutine() > before {
+			if time.Now().After(deadline) {
+				t.Fatalf("Expected: %d goroutines, Got: %d", before, runtime.NumGoroutine())
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+}
+
+func TestWaitFor(t *testing.T) {
+	checkNoLeaks(t)
+
+	s := concurrentset.New[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	tests := []struct {
+		name   string
+		wait   func() error
+		change func()
+	}{
+		{
+			name:   "WaitFor",
+			wait:   func() error { return s.WaitFor(ctx, 1) },
+			change: func() { s.InsertAll(2, 1) },
+		},
+		{
+			name:   "WaitAbsent",
+			wait:   func() error { return s.WaitAbsent(ctx, 2) },
+			change: func() { s.Delete(2) },
+		},
+		{
+			name:   "WaitUntilEmpty",
+			wait:   func() error { return s.WaitUntilEmpty(ctx) },
+			change: func() { s.Delete(1) },
+		},
+	}
+
+	for _, test := range tests {
+		errs := make(chan error, 1)
+		go func() { errs <- test.wait() }()
+		test.change()
+
+		if err := <-errs; err != nil {
+			t.Fatalf("%s: Expected: no error, Got: %v", test.name, err)
+		}
+	}
+}
+
+func TestWaitCanceled(t *testing.T) {
+	checkNoLeaks(t)
+
+	s := concurrentset.New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errs := make(chan error, 2)
+	go func() { errs <- s.WaitFor(ctx, 1) }()
+	go func() {
+		_, err := s.TakeAny(ctx)
+		errs <- err
+	}()
+
+	cancel()
+
+	for range 2 {
+		if err := <-errs; !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected: %v, Got: %v", context.Canceled, err)
+		}
+	}
+}
+
+func TestTakeAny(t *testing.T) {
+	checkNoLeaks(t)
+
+	s := concurrentset.New[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var mu sync.Mutex
+	taken := make(map[int]int)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 25 {
+				k, err := s.TakeAny(ctx)
+				if err != nil {
+					t.Errorf("Expected: no error, Got: %v", err)
+					return
+				}
+
+				mu.Lock()
+				taken[k]++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for k := range 100 {
+		s.Insert(k)
+	}
+	wg.Wait()
+
+	if len(taken) != 100 || !s.Empty() {
+		t.Fatalf("Expected: 100 distinct elements taken, Got: %d", len(taken))
+	}
+
+	for k, n := range taken {
+		if n != 1 {
+			t.Fatalf("Expected: %d to be taken once, Got: %d times", k, n)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package crdtset
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// A `Clock` provides the timestamps that order the writes to an LWWSet.
+type Clock interface {
+	// Returns the current timestamp.
+	Now() int64
+	// Called with every timestamp received from another replica.
+	Observe(t int64)
+}
+
+// A `SystemClock` uses the wall clock, in nanoseconds since the Unix epoch.
+//
+// Writes are only ordered as well as the clocks of the replicas are synchronized.
+type SystemClock struct{}
+
+// Returns the current time in nanoseconds.
+func (SystemClock) Now() int64 {
+	return time.Now().UnixNano()
+}
+
+// Does nothing, the wall clock can't be moved.
+func (SystemClock) Observe(int64) {}
+
+// A `LamportClock` is a logical clock: a counter that moves past every timestamp it
+// observes, so a write always wins over the writes its replica has already seen.
+//
+// The zero value is ready to use, and it's safe for concurrent use.
+type LamportClock struct {
+	t atomic.Int64
+}
+
+// Increments the clock and returns its new value.
+func (c *LamportClock) Now() int64 {
+	return c.t.Add(1)
+}
+
+// Moves the clock to `t` if it's behind.
+func (c *LamportClock) Observe(t int64) {
+	for {
+		cur := c.t.Load()
+		if cur >= t || c.t.CompareAndSwap(cur, t) {
+			return
+		}
+	}
+}
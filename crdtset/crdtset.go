@@ -0,0 +1,61 @@
+// Package crdtset provides sets that replicas can change independently and merge
+// without any coordination.
+//
+// Every type in this package is a state-based CRDT (conflict-free replicated data type):
+// merging is commutative, associative and idempotent, so replicas that have seen the same
+// changes hold the same set, in whatever order and however many times they merged.
+//
+//   - GSet only grows.
+//   - TwoPhaseSet allows removing an element, after which it can never be added back.
+//   - ORSet allows adding an element back after removing it, and keeps it when an add
+//     and a remove happen concurrently.
+//   - LWWSet keeps the last write of each element, as ordered by a Clock.
+//
+// Besides merging whole states, each type records the changes made since the last call
+// to `Delta`. A delta is a set of the same type that only holds those changes, and is
+// imported by merging it, which keeps the data sent between replicas small.
+// The types encode to JSON and, through encoding/gob, to binary. They are not safe for
+// concurrent use.
+package crdtset
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/Jamlie/set"
+)
+
+func encode(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decode(b []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(b)).Decode(v)
+}
+
+func sameElements[T comparable](a, b *set.Set[T]) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+
+	for k := range a.All() {
+		if !b.Contains(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func keys[T comparable](s *set.Set[T]) []T {
+	if s.Empty() {
+		return []T{}
+	}
+
+	return s.Keys()
+}
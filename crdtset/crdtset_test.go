@@ -0,0 +1,150 @@
+package crdtset_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/Jamlie/set/crdtset"
+)
+
+type replica[S any] interface {
+	Insert(int)
+	Delete(int)
+	Merge(S)
+	Delta() S
+	Clone() S
+	Equal(S) bool
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// gsetReplica adapts a GSet, which can't delete, to the replica interface.
+type gsetReplica struct {
+	*crdtset.GSet[int]
+}
+
+func (g gsetReplica) Delete(int) {}
+
+func (g gsetReplica) Merge(other gsetReplica) { g.GSet.Merge(other.GSet) }
+
+func (g gsetReplica) Delta() gsetReplica { return gsetReplica{g.GSet.Delta()} }
+
+func (g gsetReplica) Clone() gsetReplica { return gsetReplica{g.GSet.Clone()} }
+
+func (g gsetReplica) Equal(other gsetReplica) bool { return g.GSet.Equal(other.GSet) }
+
+// randomOps applies random inserts and deletes to `s` and to a copy following it
+// through deltas, then checks both converged.
+func randomOps[S replica[S]](t *testing.T, r *rand.Rand, s S) {
+	t.Helper()
+	follower := s.Clone()
+
+	for range 50 {
+		k := r.IntN(10)
+		if r.IntN(3) == 0 {
+			s.Delete(k)
+		} else {
+			s.Insert(k)
+		}
+
+		if r.IntN(5) == 0 {
+			follower.Merge(s.Delta())
+		}
+	}
+	follower.Merge(s.Delta())
+
+	if !follower.Equal(s) {
+		t.Fatalf("Expected: %v, Got: %v", s, follower)
+	}
+}
+
+func checkMerge[S replica[S]](t *testing.T, newReplica func(name string) S) {
+	t.Helper()
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for i := range 50 {
+		a, b, c := newReplica("a"), newReplica("b"), newReplica("c")
+		randomOps(t, r, a)
+		randomOps(t, r, b)
+		b.Merge(a.Clone())
+		randomOps(t, r, b)
+		randomOps(t, r, c)
+
+		ab := a.Clone()
+		ab.Merge(b)
+		ba := b.Clone()
+		ba.Merge(a)
+		if !ab.Equal(ba) {
+			t.Fatalf("Index: %d, Expected: commutative merge, Got: %v and %v", i, ab, ba)
+		}
+
+		left := ab.Clone()
+		left.Merge(c)
+		bc := b.Clone()
+		bc.Merge(c)
+		right := a.Clone()
+		right.Merge(bc)
+		if !left.Equal(right) {
+			t.Fatalf("Index: %d, Expected: associative merge, Got: %v and %v", i, left, right)
+		}
+
+		aa := a.Clone()
+		aa.Merge(a)
+		if !aa.Equal(a) {
+			t.Fatalf("Index: %d, Expected: idempotent merge, Got: %v and %v", i, aa, a)
+		}
+
+		decoded := newReplica("a")
+		if err := roundTrip(left, decoded); err != nil || !decoded.Equal(left) {
+			t.Fatalf("Index: %d, Expected: %v, Got: %v, %v", i, left, decoded, err)
+		}
+	}
+}
+
+func roundTrip[S replica[S]](s S, into S) error {
+	b, err := s.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := into.UnmarshalBinary(b); err != nil {
+		return err
+	}
+	if !into.Equal(s) {
+		return fmt.Errorf("binary decoding lost state")
+	}
+
+	b, err = s.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return into.UnmarshalJSON(b)
+}
+
+func TestMergeProperties(t *testing.T) {
+	t.Run("GSet", func(t *testing.T) {
+		checkMerge(t, func(string) gsetReplica {
+			return gsetReplica{crdtset.NewGSet[int]()}
+		})
+	})
+
+	t.Run("TwoPhaseSet", func(t *testing.T) {
+		checkMerge(t, func(string) *crdtset.TwoPhaseSet[int] {
+			return crdtset.NewTwoPhaseSet[int]()
+		})
+	})
+
+	t.Run("ORSet", func(t *testing.T) {
+		checkMerge(t, crdtset.NewORSet[int])
+	})
+
+	t.Run("LWWSet", func(t *testing.T) {
+		checkMerge(t, func(name string) *crdtset.LWWSet[int] {
+			return crdtset.NewLWWSet[int](name, &crdtset.LamportClock{})
+		})
+	})
+}
@@ -0,0 +1,176 @@
+package crdtset
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/Jamlie/set"
+)
+
+// A `GSet` is a grow-only set: elements can be added but never removed.
+//
+// Merging two GSets is their union.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/crdtset"
+//	)
+//
+//	func main() {
+//		a := crdtset.NewGSet[string]()
+//		b := crdtset.NewGSet[string]()
+//		a.Insert("eu-west")
+//		b.Insert("us-east")
+//
+//		a.Merge(b)
+//		fmt.Println(a.Len()) // 2
+//	}
+type GSet[T comparable] struct {
+	added   *set.Set[T]
+	pending *set.Set[T]
+}
+
+// Create a new empty GSet.
+func NewGSet[T comparable]() *GSet[T] {
+	return &GSet[T]{
+		added:   set.New[T](),
+		pending: set.New[T](),
+	}
+}
+
+// Adds a value to the set.
+func (g *GSet[T]) Insert(k T) {
+	if !g.added.Contains(k) {
+		g.added.Insert(k)
+		g.pending.Insert(k)
+	}
+}
+
+// Returns `true` if the set contains a value.
+func (g *GSet[T]) Contains(k T) bool {
+	return g.added.Contains(k)
+}
+
+// The number of elements the set has.
+func (g *GSet[T]) Len() int {
+	return g.added.Len()
+}
+
+// Returns a slice containing the keys of the set in an arbitrary order.
+func (g *GSet[T]) Keys() []T {
+	return g.added.Keys()
+}
+
+// A way to iterate through the set using a range-loop
+func (g *GSet[T]) All() iter.Seq[T] {
+	return g.added.All()
+}
+
+// Returns a stringified version of the set with elements in an arbitrary order
+func (g GSet[T]) String() string {
+	return fmt.Sprint(g.added.Keys())
+}
+
+// Adds the elements of `other`, which may be a whole replica or a delta.
+func (g *GSet[T]) Merge(other *GSet[T]) {
+	g.added.InsertSeq(other.added.All())
+}
+
+// Returns the elements added since the last call as a GSet, to be merged into other
+// replicas.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/crdtset"
+//	)
+//
+//	func main() {
+//		a := crdtset.NewGSet[int]()
+//		b := crdtset.NewGSet[int]()
+//		a.Insert(1)
+//		b.Merge(a.Delta())
+//		a.Insert(2)
+//		b.Merge(a.Delta()) // only holds 2
+//
+//		fmt.Println(b.Len()) // 2
+//	}
+func (g *GSet[T]) Delta() *GSet[T] {
+	d := &GSet[T]{
+		added:   g.pending,
+		pending: set.New[T](),
+	}
+	g.pending = set.New[T]()
+
+	return d
+}
+
+// Returns a copy of the set, without the changes waiting for `Delta`.
+func (g *GSet[T]) Clone() *GSet[T] {
+	return &GSet[T]{
+		added:   g.added.Clone(),
+		pending: set.New[T](),
+	}
+}
+
+// Returns `true` if both replicas hold the same state.
+func (g *GSet[T]) Equal(other *GSet[T]) bool {
+	return sameElements(g.added, other.added)
+}
+
+type gsetWire[T comparable] struct {
+	Added []T `json:"added"`
+}
+
+func (g *GSet[T]) wire() gsetWire[T] {
+	return gsetWire[T]{Added: keys(g.added)}
+}
+
+func (g *GSet[T]) load(w gsetWire[T]) {
+	*g = GSet[T]{
+		added:   set.FromSlice(w.Added),
+		pending: set.New[T](),
+	}
+}
+
+// Encodes the set as a JSON object with the `added` elements as an array.
+func (g *GSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.wire())
+}
+
+// Decodes a set encoded by MarshalJSON, replacing the state of the set.
+func (g *GSet[T]) UnmarshalJSON(b []byte) error {
+	var w gsetWire[T]
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+
+	g.load(w)
+	return nil
+}
+
+// Encodes the set with encoding/gob.
+func (g *GSet[T]) MarshalBinary() ([]byte, error) {
+	return encode(g.wire())
+}
+
+// Decodes a set encoded by MarshalBinary, replacing the state of the set.
+func (g *GSet[T]) UnmarshalBinary(b []byte) error {
+	var w gsetWire[T]
+	if err := decode(b, &w); err != nil {
+		return err
+	}
+
+	g.load(w)
+	return nil
+}
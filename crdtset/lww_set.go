@@ -0,0 +1,288 @@
+package crdtset
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+)
+
+// A `Stamp` orders the writes to an LWWSet, by time and then by replica.
+type Stamp struct {
+	Time    int64  `json:"time"`
+	Replica string `json:"replica"`
+}
+
+func (s Stamp) compare(other Stamp) int {
+	if c := cmp.Compare(s.Time, other.Time); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(s.Replica, other.Replica)
+}
+
+// An `LWWSet` is a last-writer-wins element set: every insertion and removal is
+// stamped by a Clock, and for each element the latest of them decides whether it's
+// in the set.
+//
+// An insertion and a removal with the same stamp leave the element in the set. A local
+// write is always stamped after the writes to the same element this replica has seen,
+// even when the clock lags behind.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/crdtset"
+//	)
+//
+//	func main() {
+//		a := crdtset.NewLWWSet[string]("a", &crdtset.LamportClock{})
+//		b := crdtset.NewLWWSet[string]("b", &crdtset.LamportClock{})
+//
+//		a.Insert("x")
+//		b.Merge(a)
+//		b.Delete("x")
+//		a.Merge(b)
+//
+//		fmt.Println(a.Contains("x")) // false
+//	}
+type LWWSet[T comparable] struct {
+	replica string
+	clock   Clock
+	adds    map[T]Stamp
+	removes map[T]Stamp
+	pending *LWWSet[T]
+}
+
+// Create a new empty LWWSet for the replica `replica`, stamping writes with `clock`.
+//
+// The replica breaks ties between writes with the same time, so it should be unique
+// among the replicas that will be merged together. A nil clock is a SystemClock.
+func NewLWWSet[T comparable](replica string, clock Clock) *LWWSet[T] {
+	s := newLWWState[T](replica, clock)
+	s.pending = newLWWState[T](replica, clock)
+	return s
+}
+
+func newLWWState[T comparable](replica string, clock Clock) *LWWSet[T] {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+
+	return &LWWSet[T]{
+		replica: replica,
+		clock:   clock,
+		adds:    make(map[T]Stamp),
+		removes: make(map[T]Stamp),
+	}
+}
+
+// stamp returns a stamp later than the clock and every write to `k` seen so far.
+func (s *LWWSet[T]) stamp(k T) Stamp {
+	t := s.clock.Now()
+	for _, m := range []map[T]Stamp{s.adds, s.removes} {
+		if prev, ok := m[k]; ok && prev.Time >= t {
+			t = prev.Time + 1
+		}
+	}
+
+	return Stamp{Time: t, Replica: s.replica}
+}
+
+// put keeps `stamp` for `k` in `m` if it's later than the one there.
+func put[T comparable](m map[T]Stamp, k T, stamp Stamp) {
+	if prev, ok := m[k]; !ok || prev.compare(stamp) < 0 {
+		m[k] = stamp
+	}
+}
+
+// Adds a value to the set.
+func (s *LWWSet[T]) Insert(k T) {
+	stamp := s.stamp(k)
+	put(s.adds, k, stamp)
+	put(s.pending.adds, k, stamp)
+}
+
+// Removes a value from the set.
+//
+// The removal is recorded even if the value is not in the set, so it also wins over
+// earlier insertions this replica has not seen yet.
+func (s *LWWSet[T]) Delete(k T) {
+	stamp := s.stamp(k)
+	put(s.removes, k, stamp)
+	put(s.pending.removes, k, stamp)
+}
+
+// Returns `true` if the set contains a value.
+func (s *LWWSet[T]) Contains(k T) bool {
+	add, ok := s.adds[k]
+	if !ok {
+		return false
+	}
+
+	remove, ok := s.removes[k]
+	return !ok || remove.compare(add) <= 0
+}
+
+// The number of elements the set has.
+func (s *LWWSet[T]) Len() int {
+	n := 0
+	for range s.All() {
+		n++
+	}
+
+	return n
+}
+
+// Returns a slice containing the keys of the set in an arbitrary order.
+func (s *LWWSet[T]) Keys() []T {
+	keys := make([]T, 0, len(s.adds))
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// A way to iterate through the set using a range-loop
+func (s *LWWSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s.adds {
+			if s.Contains(k) && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a stringified version of the set with elements in an arbitrary order
+func (s LWWSet[T]) String() string {
+	return fmt.Sprint(s.Keys())
+}
+
+// Merges the state of `other`, which may be a whole replica or a delta, keeping the
+// latest write of each element. Every merged timestamp is passed to the clock.
+func (s *LWWSet[T]) Merge(other *LWWSet[T]) {
+	for k, stamp := range other.adds {
+		put(s.adds, k, stamp)
+		s.clock.Observe(stamp.Time)
+	}
+
+	for k, stamp := range other.removes {
+		put(s.removes, k, stamp)
+		s.clock.Observe(stamp.Time)
+	}
+}
+
+// Returns the changes made since the last call as an LWWSet, to be merged into other
+// replicas.
+func (s *LWWSet[T]) Delta() *LWWSet[T] {
+	d := s.pending
+	d.pending = newLWWState[T](s.replica, s.clock)
+	s.pending = newLWWState[T](s.replica, s.clock)
+
+	return d
+}
+
+// Returns a copy of the set, without the changes waiting for `Delta`.
+//
+// The copy shares the clock and keeps the replica of the set, so only one of them
+// should be changed.
+func (s *LWWSet[T]) Clone() *LWWSet[T] {
+	return &LWWSet[T]{
+		replica: s.replica,
+		clock:   s.clock,
+		adds:    maps.Clone(s.adds),
+		removes: maps.Clone(s.removes),
+		pending: newLWWState[T](s.replica, s.clock),
+	}
+}
+
+// Returns `true` if both replicas hold the same state, regardless of which replica
+// each of them is.
+func (s *LWWSet[T]) Equal(other *LWWSet[T]) bool {
+	return maps.Equal(s.adds, other.adds) && maps.Equal(s.removes, other.removes)
+}
+
+type lwwEntryWire[T comparable] struct {
+	Value T `json:"value"`
+	Stamp
+}
+
+type lwwSetWire[T comparable] struct {
+	Replica string            `json:"replica"`
+	Adds    []lwwEntryWire[T] `json:"adds"`
+	Removes []lwwEntryWire[T] `json:"removes"`
+}
+
+func entries[T comparable](m map[T]Stamp) []lwwEntryWire[T] {
+	w := make([]lwwEntryWire[T], 0, len(m))
+	for k, stamp := range m {
+		w = append(w, lwwEntryWire[T]{Value: k, Stamp: stamp})
+	}
+
+	return w
+}
+
+func (s *LWWSet[T]) wire() lwwSetWire[T] {
+	return lwwSetWire[T]{
+		Replica: s.replica,
+		Adds:    entries(s.adds),
+		Removes: entries(s.removes),
+	}
+}
+
+// load replaces the state of the set, keeping its clock.
+func (s *LWWSet[T]) load(w lwwSetWire[T]) {
+	*s = *NewLWWSet[T](w.Replica, s.clock)
+
+	for _, e := range w.Adds {
+		put(s.adds, e.Value, e.Stamp)
+		s.clock.Observe(e.Time)
+	}
+
+	for _, e := range w.Removes {
+		put(s.removes, e.Value, e.Stamp)
+		s.clock.Observe(e.Time)
+	}
+}
+
+// Encodes the set as a JSON object holding its replica and the stamped `adds` and
+// `removes` of every element. The clock is not encoded.
+func (s *LWWSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.wire())
+}
+
+// Decodes a set encoded by MarshalJSON, replacing the state of the set. The set keeps
+// its clock, or uses a SystemClock if it has none.
+func (s *LWWSet[T]) UnmarshalJSON(b []byte) error {
+	var w lwwSetWire[T]
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+
+	s.load(w)
+	return nil
+}
+
+// Encodes the set with encoding/gob. The clock is not encoded.
+func (s *LWWSet[T]) MarshalBinary() ([]byte, error) {
+	return encode(s.wire())
+}
+
+// Decodes a set encoded by MarshalBinary, replacing the state of the set. The set keeps
+// its clock, or uses a SystemClock if it has none.
+func (s *LWWSet[T]) UnmarshalBinary(b []byte) error {
+	var w lwwSetWire[T]
+	if err := decode(b, &w); err != nil {
+		return err
+	}
+
+	s.load(w)
+	return nil
+}
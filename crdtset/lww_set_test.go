@@ -0,0 +1,30 @@
+package crdtset_test
+
+import (
+	"testing"
+
+	"github.com/Jamlie/set/crdtset"
+)
+
+func TestLWWSetLastWriteWins(t *testing.T) {
+	a := crdtset.NewLWWSet[string]("a", &crdtset.LamportClock{})
+	b := crdtset.NewLWWSet[string]("b", &crdtset.LamportClock{})
+
+	a.Insert("x")
+	b.Merge(a.Delta())
+	b.Delete("x")
+	a.Merge(b.Delta())
+
+	if a.Contains("x") {
+		t.Fatalf("Expected: x removed, Got: %s", a)
+	}
+
+	// Concurrent writes with the same time are ordered by replica.
+	a.Insert("y")
+	b.Delete("y")
+	a.Merge(b.Delta())
+	b.Merge(a.Delta())
+	if a.Contains("y") || b.Contains("y") {
+		t.Fatalf("Expected: y removed by the later replica, Got: %s and %s", a, b)
+	}
+}
@@ -0,0 +1,306 @@
+package crdtset
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/Jamlie/set"
+)
+
+// A `Tag` uniquely identifies one insertion into an ORSet.
+type Tag struct {
+	Replica string `json:"replica"`
+	Seq     uint64 `json:"seq"`
+}
+
+// An `ORSet` is an observed-remove set: an element can be removed and added back any
+// number of times.
+//
+// Every insertion is given a unique Tag, and removing an element removes the tags this
+// replica has seen for it. An insertion made concurrently on another replica has a tag
+// that was not removed, so the element stays after merging: adds win over concurrent
+// removes. Removed tags are kept as tombstones, so the state grows with the number of
+// insertions.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/crdtset"
+//	)
+//
+//	func main() {
+//		a := crdtset.NewORSet[string]("a")
+//		a.Insert("x")
+//
+//		b := a.Clone()
+//		a.Delete("x")
+//		b.Insert("x") // concurrent with the delete
+//
+//		a.Merge(b)
+//		fmt.Println(a.Contains("x")) // true
+//	}
+type ORSet[T comparable] struct {
+	replica string
+	seq     uint64
+	// entries holds the tags of every element that have not been removed.
+	entries    map[T]*set.Set[Tag]
+	tombstones *set.Set[Tag]
+	pending    *ORSet[T]
+}
+
+// Create a new empty ORSet for the replica `replica`, which must be unique among the
+// replicas that will be merged together.
+func NewORSet[T comparable](replica string) *ORSet[T] {
+	s := newORState[T](replica)
+	s.pending = newORState[T](replica)
+	return s
+}
+
+func newORState[T comparable](replica string) *ORSet[T] {
+	return &ORSet[T]{
+		replica:    replica,
+		entries:    make(map[T]*set.Set[Tag]),
+		tombstones: set.New[Tag](),
+	}
+}
+
+// add records a tag for `k` unless it has been removed, and returns whether it did.
+func (s *ORSet[T]) add(k T, tag Tag) bool {
+	if s.tombstones.Contains(tag) {
+		return false
+	}
+
+	tags, ok := s.entries[k]
+	if !ok {
+		tags = set.New[Tag]()
+		s.entries[k] = tags
+	}
+	tags.Insert(tag)
+
+	if tag.Replica == s.replica {
+		s.seq = max(s.seq, tag.Seq)
+	}
+
+	return true
+}
+
+// Adds a value to the set.
+func (s *ORSet[T]) Insert(k T) {
+	tag := Tag{Replica: s.replica, Seq: s.seq + 1}
+	s.add(k, tag)
+	s.pending.add(k, tag)
+}
+
+// Removes a value from the set, along with every insertion of it this replica has seen.
+//
+// Removing a value that is not in the set will result in nothing.
+func (s *ORSet[T]) Delete(k T) {
+	tags, ok := s.entries[k]
+	if !ok {
+		return
+	}
+
+	for tag := range tags.All() {
+		s.tombstones.Insert(tag)
+		s.pending.tombstones.Insert(tag)
+	}
+
+	delete(s.entries, k)
+	delete(s.pending.entries, k)
+}
+
+// Returns `true` if the set contains a value.
+func (s *ORSet[T]) Contains(k T) bool {
+	_, ok := s.entries[k]
+	return ok
+}
+
+// The number of elements the set has.
+func (s *ORSet[T]) Len() int {
+	return len(s.entries)
+}
+
+// Returns a slice containing the keys of the set in an arbitrary order.
+func (s *ORSet[T]) Keys() []T {
+	keys := make([]T, 0, len(s.entries))
+	for k := range s.entries {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// A way to iterate through the set using a range-loop
+func (s *ORSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s.entries {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a stringified version of the set with elements in an arbitrary order
+func (s ORSet[T]) String() string {
+	return fmt.Sprint(s.Keys())
+}
+
+// Merges the state of `other`, which may be a whole replica or a delta.
+func (s *ORSet[T]) Merge(other *ORSet[T]) {
+	removed := false
+	for tag := range other.tombstones.All() {
+		if !s.tombstones.Contains(tag) {
+			s.tombstones.Insert(tag)
+			removed = true
+		}
+		if tag.Replica == s.replica {
+			s.seq = max(s.seq, tag.Seq)
+		}
+	}
+
+	if removed {
+		for k, tags := range s.entries {
+			for _, tag := range tags.Keys() {
+				if s.tombstones.Contains(tag) {
+					tags.Delete(tag)
+				}
+			}
+
+			if tags.Empty() {
+				delete(s.entries, k)
+			}
+		}
+	}
+
+	for k, tags := range other.entries {
+		for tag := range tags.All() {
+			s.add(k, tag)
+		}
+	}
+}
+
+// Returns the changes made since the last call as an ORSet, to be merged into other
+// replicas.
+func (s *ORSet[T]) Delta() *ORSet[T] {
+	d := s.pending
+	d.seq = s.seq
+	d.pending = newORState[T](s.replica)
+	s.pending = newORState[T](s.replica)
+
+	return d
+}
+
+// Returns a copy of the set, without the changes waiting for `Delta`.
+//
+// The copy keeps the replica of the set, so only one of them should be changed.
+func (s *ORSet[T]) Clone() *ORSet[T] {
+	c := &ORSet[T]{
+		replica:    s.replica,
+		seq:        s.seq,
+		entries:    make(map[T]*set.Set[Tag], len(s.entries)),
+		tombstones: s.tombstones.Clone(),
+		pending:    newORState[T](s.replica),
+	}
+
+	for k, tags := range s.entries {
+		c.entries[k] = tags.Clone()
+	}
+
+	return c
+}
+
+// Returns `true` if both replicas hold the same state, regardless of which replica
+// each of them is.
+func (s *ORSet[T]) Equal(other *ORSet[T]) bool {
+	if len(s.entries) != len(other.entries) || !sameElements(s.tombstones, other.tombstones) {
+		return false
+	}
+
+	for k, tags := range s.entries {
+		o, ok := other.entries[k]
+		if !ok || !sameElements(tags, o) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type orEntryWire[T comparable] struct {
+	Value T     `json:"value"`
+	Tags  []Tag `json:"tags"`
+}
+
+type orSetWire[T comparable] struct {
+	Replica    string           `json:"replica"`
+	Seq        uint64           `json:"seq"`
+	Entries    []orEntryWire[T] `json:"entries"`
+	Tombstones []Tag            `json:"tombstones"`
+}
+
+func (s *ORSet[T]) wire() orSetWire[T] {
+	w := orSetWire[T]{
+		Replica:    s.replica,
+		Seq:        s.seq,
+		Entries:    make([]orEntryWire[T], 0, len(s.entries)),
+		Tombstones: keys(s.tombstones),
+	}
+
+	for k, tags := range s.entries {
+		w.Entries = append(w.Entries, orEntryWire[T]{Value: k, Tags: tags.Keys()})
+	}
+
+	return w
+}
+
+func (s *ORSet[T]) load(w orSetWire[T]) {
+	*s = *newORState[T](w.Replica)
+	s.seq = w.Seq
+	s.tombstones = set.FromSlice(w.Tombstones)
+	s.pending = newORState[T](w.Replica)
+
+	for _, e := range w.Entries {
+		for _, tag := range e.Tags {
+			s.add(e.Value, tag)
+		}
+	}
+}
+
+// Encodes the set as a JSON object holding its replica, its elements with their tags
+// and its tombstones.
+func (s *ORSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.wire())
+}
+
+// Decodes a set encoded by MarshalJSON, replacing the state of the set.
+func (s *ORSet[T]) UnmarshalJSON(b []byte) error {
+	var w orSetWire[T]
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+
+	s.load(w)
+	return nil
+}
+
+// Encodes the set with encoding/gob.
+func (s *ORSet[T]) MarshalBinary() ([]byte, error) {
+	return encode(s.wire())
+}
+
+// Decodes a set encoded by MarshalBinary, replacing the state of the set.
+func (s *ORSet[T]) UnmarshalBinary(b []byte) error {
+	var w orSetWire[T]
+	if err := decode(b, &w); err != nil {
+		return err
+	}
+
+	s.load(w)
+	return nil
+}
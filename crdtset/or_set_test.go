@@ -0,0 +1,31 @@
+package crdtset_test
+
+import (
+	"testing"
+
+	"github.com/Jamlie/set/crdtset"
+)
+
+func TestORSetAddWins(t *testing.T) {
+	a := crdtset.NewORSet[string]("a")
+	b := crdtset.NewORSet[string]("b")
+	a.Insert("x")
+	b.Merge(a.Delta())
+
+	a.Delete("x")
+	b.Insert("x")
+
+	a.Merge(b.Delta())
+	b.Merge(a.Delta())
+
+	if !a.Contains("x") || !b.Contains("x") {
+		t.Fatalf("Expected: x kept on both replicas, Got: %s and %s", a, b)
+	}
+
+	b.Delete("x")
+	a.Merge(b.Delta())
+	a.Insert("x")
+	if !a.Contains("x") {
+		t.Fatalf("Expected: x added back, Got: %s", a)
+	}
+}
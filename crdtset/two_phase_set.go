@@ -0,0 +1,194 @@
+package crdtset
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+
+	"github.com/Jamlie/set"
+)
+
+// A `TwoPhaseSet` is a set where an element can be added and then removed, but never
+// added again once removed.
+//
+// It holds a grow-only set of added elements and one of removed elements, and merging
+// two TwoPhaseSets is the union of each.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/crdtset"
+//	)
+//
+//	func main() {
+//		v := crdtset.NewTwoPhaseSet[string]()
+//		v.Insert("session-1")
+//		v.Delete("session-1")
+//		v.Insert("session-1")
+//
+//		fmt.Println(v.Contains("session-1")) // false
+//	}
+type TwoPhaseSet[T comparable] struct {
+	added   *set.Set[T]
+	removed *set.Set[T]
+	pending *TwoPhaseSet[T]
+}
+
+// Create a new empty TwoPhaseSet.
+func NewTwoPhaseSet[T comparable]() *TwoPhaseSet[T] {
+	s := newTwoPhaseState[T]()
+	s.pending = newTwoPhaseState[T]()
+	return s
+}
+
+func newTwoPhaseState[T comparable]() *TwoPhaseSet[T] {
+	return &TwoPhaseSet[T]{
+		added:   set.New[T](),
+		removed: set.New[T](),
+	}
+}
+
+// Adds a value to the set, unless it has been removed before.
+func (s *TwoPhaseSet[T]) Insert(k T) {
+	if !s.added.Contains(k) && !s.removed.Contains(k) {
+		s.added.Insert(k)
+		s.pending.added.Insert(k)
+	}
+}
+
+// Removes a value from the set for good.
+//
+// Removing a value that is not in the set will result in nothing.
+func (s *TwoPhaseSet[T]) Delete(k T) {
+	if s.Contains(k) {
+		s.removed.Insert(k)
+		s.pending.removed.Insert(k)
+	}
+}
+
+// Returns `true` if the set contains a value.
+func (s *TwoPhaseSet[T]) Contains(k T) bool {
+	return s.added.Contains(k) && !s.removed.Contains(k)
+}
+
+// The number of elements the set has.
+func (s *TwoPhaseSet[T]) Len() int {
+	n := 0
+	for range s.All() {
+		n++
+	}
+
+	return n
+}
+
+// Returns a slice containing the keys of the set in an arbitrary order.
+func (s *TwoPhaseSet[T]) Keys() []T {
+	keys := make([]T, 0, s.added.Len())
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// A way to iterate through the set using a range-loop
+func (s *TwoPhaseSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s.added.All() {
+			if !s.removed.Contains(k) && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a stringified version of the set with elements in an arbitrary order
+func (s TwoPhaseSet[T]) String() string {
+	return fmt.Sprint(s.Keys())
+}
+
+// Merges the state of `other`, which may be a whole replica or a delta.
+func (s *TwoPhaseSet[T]) Merge(other *TwoPhaseSet[T]) {
+	s.added.InsertSeq(other.added.All())
+	s.removed.InsertSeq(other.removed.All())
+}
+
+// Returns the changes made since the last call as a TwoPhaseSet, to be merged into
+// other replicas.
+func (s *TwoPhaseSet[T]) Delta() *TwoPhaseSet[T] {
+	d := s.pending
+	d.pending = newTwoPhaseState[T]()
+	s.pending = newTwoPhaseState[T]()
+
+	return d
+}
+
+// Returns a copy of the set, without the changes waiting for `Delta`.
+func (s *TwoPhaseSet[T]) Clone() *TwoPhaseSet[T] {
+	return &TwoPhaseSet[T]{
+		added:   s.added.Clone(),
+		removed: s.removed.Clone(),
+		pending: newTwoPhaseState[T](),
+	}
+}
+
+// Returns `true` if both replicas hold the same state.
+func (s *TwoPhaseSet[T]) Equal(other *TwoPhaseSet[T]) bool {
+	return sameElements(s.added, other.added) && sameElements(s.removed, other.removed)
+}
+
+type twoPhaseWire[T comparable] struct {
+	Added   []T `json:"added"`
+	Removed []T `json:"removed"`
+}
+
+func (s *TwoPhaseSet[T]) wire() twoPhaseWire[T] {
+	return twoPhaseWire[T]{
+		Added:   keys(s.added),
+		Removed: keys(s.removed),
+	}
+}
+
+func (s *TwoPhaseSet[T]) load(w twoPhaseWire[T]) {
+	*s = TwoPhaseSet[T]{
+		added:   set.FromSlice(w.Added),
+		removed: set.FromSlice(w.Removed),
+		pending: newTwoPhaseState[T](),
+	}
+}
+
+// Encodes the set as a JSON object with the `added` and `removed` elements as arrays.
+func (s *TwoPhaseSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.wire())
+}
+
+// Decodes a set encoded by MarshalJSON, replacing the state of the set.
+func (s *TwoPhaseSet[T]) UnmarshalJSON(b []byte) error {
+	var w twoPhaseWire[T]
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+
+	s.load(w)
+	return nil
+}
+
+// Encodes the set with encoding/gob.
+func (s *TwoPhaseSet[T]) MarshalBinary() ([]byte, error) {
+	return encode(s.wire())
+}
+
+// Decodes a set encoded by MarshalBinary, replacing the state of the set.
+func (s *TwoPhaseSet[T]) UnmarshalBinary(b []byte) error {
+	var w twoPhaseWire[T]
+	if err := decode(b, &w); err != nil {
+		return err
+	}
+
+	s.load(w)
+	return nil
+}
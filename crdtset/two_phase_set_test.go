@@ -0,0 +1,31 @@
+package crdtset_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Jamlie/set/crdtset"
+)
+
+func TestTwoPhaseSetRemoveIsFinal(t *testing.T) {
+	a := crdtset.NewTwoPhaseSet[string]()
+	a.Insert("x")
+	b := a.Clone()
+
+	a.Delete("x")
+	b.Insert("x")
+	b.Merge(a)
+	b.Insert("x")
+
+	if b.Contains("x") {
+		t.Fatalf("Expected: x removed for good, Got: %s", b)
+	}
+
+	got, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+	if expect := `{"added":["x"],"removed":["x"]}`; string(got) != expect {
+		t.Fatalf("Expected: %s, Got: %s", expect, got)
+	}
+}
@@ -0,0 +1,222 @@
+package set
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// A `Delta` holds the changes that turn one Set into another.
+//
+// Added and Removed never share an element. Deltas can be applied to a set with
+// `Set.Apply`, undone with `Set.Revert`, chained with Compose and sent to other
+// processes as JSON.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"encoding/json"
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		old := set.FromSlice([]string{"a", "b"})
+//		new := set.FromSlice([]string{"b", "c"})
+//
+//		delta := set.Diff(old, new)
+//		fmt.Println(delta.Added, delta.Removed) // [c] [a]
+//
+//		b, _ := json.Marshal(delta)
+//		fmt.Println(string(b)) // {"added":["c"],"removed":["a"]}
+//
+//		replica := set.FromSlice([]string{"a", "b"})
+//		replica.Apply(delta)
+//		fmt.Println(replica) // [b c]
+//	}
+type Delta[T comparable] struct {
+	Added   *Set[T]
+	Removed *Set[T]
+}
+
+// Returns the changes that turn `old` into `new`.
+func Diff[T comparable](old, new *Set[T]) *Delta[T] {
+	d := &Delta[T]{
+		Added:   New[T](),
+		Removed: New[T](),
+	}
+
+	for k := range new.set {
+		if !old.Contains(k) {
+			d.Added.Insert(k)
+		}
+	}
+
+	for k := range old.set {
+		if !new.Contains(k) {
+			d.Removed.Insert(k)
+		}
+	}
+
+	return d
+}
+
+// Removes the elements removed by the delta and inserts the ones it added.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		old := set.FromSlice([]int{1, 2})
+//		delta := set.Diff(old, set.FromSlice([]int{2, 3}))
+//
+//		old.Apply(delta)
+//		assert.Assert(old.Contains(3) && !old.Contains(1), "Should apply the changes")
+//	}
+func (s *Set[T]) Apply(d *Delta[T]) {
+	if d.Removed != nil {
+		for k := range d.Removed.set {
+			s.Delete(k)
+		}
+	}
+
+	if d.Added != nil {
+		s.InsertSeq(d.Added.All())
+	}
+}
+
+// Undoes the delta, the same as applying its inverse.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.FromSlice([]int{1, 2})
+//		delta := set.Diff(v, set.FromSlice([]int{2, 3}))
+//
+//		v.Apply(delta)
+//		v.Revert(delta)
+//		assert.Assert(v.Contains(1) && !v.Contains(3), "Should undo the changes")
+//	}
+func (s *Set[T]) Revert(d *Delta[T]) {
+	s.Apply(d.Invert())
+}
+
+// Returns a delta that undoes this one, swapping Added and Removed.
+func (d *Delta[T]) Invert() *Delta[T] {
+	return &Delta[T]{
+		Added:   d.Removed,
+		Removed: d.Added,
+	}
+}
+
+// Returns a single delta with the same effect as applying this delta and then `next`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v1 := set.FromSlice([]int{1})
+//		v2 := set.FromSlice([]int{1, 2})
+//		v3 := set.FromSlice([]int{2, 3})
+//
+//		delta := set.Diff(v1, v2).Compose(set.Diff(v2, v3))
+//		fmt.Println(delta.Added, delta.Removed) // [2 3] [1]
+//	}
+func (d *Delta[T]) Compose(next *Delta[T]) *Delta[T] {
+	// Elements added by one delta and removed by the other cancel out, as the
+	// deltas are expected to be consecutive.
+	return &Delta[T]{
+		Added:   union(difference(d.added(), next.removed()), difference(next.added(), d.removed())),
+		Removed: union(difference(d.removed(), next.added()), difference(next.removed(), d.added())),
+	}
+}
+
+// Returns `true` if the delta holds no changes.
+func (d *Delta[T]) Empty() bool {
+	return (d.Added == nil || d.Added.Empty()) && (d.Removed == nil || d.Removed.Empty())
+}
+
+func (d *Delta[T]) added() map[T]struct{} {
+	if d.Added == nil {
+		return nil
+	}
+	return d.Added.set
+}
+
+func (d *Delta[T]) removed() map[T]struct{} {
+	if d.Removed == nil {
+		return nil
+	}
+	return d.Removed.set
+}
+
+func difference[T comparable](a, b map[T]struct{}) map[T]struct{} {
+	result := make(map[T]struct{})
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			result[k] = struct{}{}
+		}
+	}
+	return result
+}
+
+func union[T comparable](a, b map[T]struct{}) *Set[T] {
+	maps.Copy(a, b)
+	return &Set[T]{set: a}
+}
+
+type deltaJSON[T comparable] struct {
+	Added   []T `json:"added"`
+	Removed []T `json:"removed"`
+}
+
+// Encodes the delta as a JSON object with the `added` and `removed` elements as arrays.
+func (d *Delta[T]) MarshalJSON() ([]byte, error) {
+	v := deltaJSON[T]{
+		Added:   []T{},
+		Removed: []T{},
+	}
+
+	if d.Added != nil {
+		v.Added = d.Added.Keys()
+	}
+	if d.Removed != nil {
+		v.Removed = d.Removed.Keys()
+	}
+
+	return json.Marshal(v)
+}
+
+// Decodes a delta encoded by MarshalJSON.
+func (d *Delta[T]) UnmarshalJSON(b []byte) error {
+	var v deltaJSON[T]
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	d.Added = FromSlice(v.Added)
+	d.Removed = FromSlice(v.Removed)
+	return nil
+}
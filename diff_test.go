@@ -0,0 +1,84 @@
+package set_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Jamlie/set"
+)
+
+func TestDiffApplyRevert(t *testing.T) {
+	test := struct {
+		old, new *set.Set[int]
+		added    []int
+		removed  []int
+	}{
+		old:     set.FromSlice([]int{1, 2, 3}),
+		new:     set.FromSlice([]int{2, 3, 4, 5}),
+		added:   []int{4, 5},
+		removed: []int{1},
+	}
+
+	delta := set.Diff(test.old, test.new)
+	if !sameSlice(delta.Added.Keys(), test.added) || !sameSlice(delta.Removed.Keys(), test.removed) {
+		t.Fatalf("Expected: %v and %v, Got: %s and %s", test.added, test.removed, delta.Added, delta.Removed)
+	}
+
+	s := test.old.Clone()
+	s.Apply(delta)
+	if !sameSlice(s.Keys(), test.new.Keys()) {
+		t.Fatalf("Expected: %s, Got: %s", test.new, s)
+	}
+
+	s.Revert(delta)
+	if !sameSlice(s.Keys(), test.old.Keys()) {
+		t.Fatalf("Expected: %s, Got: %s", test.old, s)
+	}
+}
+
+func TestDeltaCompose(t *testing.T) {
+	versions := []*set.Set[int]{
+		set.FromSlice([]int{1, 2}),
+		set.FromSlice([]int{2, 3}),
+		set.FromSlice([]int{1, 3, 4}),
+		set.FromSlice([]int{4}),
+	}
+
+	delta := set.Diff(versions[0], versions[1])
+	for i := 2; i < len(versions); i++ {
+		delta = delta.Compose(set.Diff(versions[i-1], versions[i]))
+	}
+
+	s := versions[0].Clone()
+	s.Apply(delta)
+	if !sameSlice(s.Keys(), []int{4}) {
+		t.Fatalf("Expected: %v, Got: %s", []int{4}, s)
+	}
+
+	s.Apply(delta.Invert())
+	if !sameSlice(s.Keys(), []int{1, 2}) {
+		t.Fatalf("Expected: %v, Got: %s", []int{1, 2}, s)
+	}
+}
+
+func TestDeltaJSON(t *testing.T) {
+	delta := set.Diff(set.FromSlice([]string{"a"}), set.FromSlice([]string{"b"}))
+
+	b, err := json.Marshal(delta)
+	if err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	if string(b) != `{"added":["b"],"removed":["a"]}` {
+		t.Fatalf("Expected: %s, Got: %s", `{"added":["b"],"removed":["a"]}`, b)
+	}
+
+	var decoded set.Delta[string]
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	if !decoded.Added.Contains("b") || !decoded.Removed.Contains("a") {
+		t.Fatalf("Expected: %s, Got: %s", b, decoded.Added)
+	}
+}
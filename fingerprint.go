@@ -0,0 +1,32 @@
+package set
+
+import "github.com/Jamlie/set/internal"
+
+// Returns a 64-bit hash of the elements of the set that doesn't depend on their order,
+// so sets with the same elements have the same fingerprint.
+//
+// Fingerprints are built on `hash/maphash` with a seed chosen when the program starts,
+// so they can be compared within a process, including with the fingerprints of an
+// `orderedset.OrderedSet` or a `concurrentset.ConcurrentSet`, but not stored or sent to
+// other processes. Different sets may share a fingerprint, if rarely.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		a := set.FromSlice([]int{1, 2, 3})
+//		b := set.FromSlice([]int{3, 2, 1})
+//		assert.Assert(a.Fingerprint() == b.Fingerprint(), "Should not depend on the order")
+//
+//		b.Delete(1)
+//		assert.Assert(a.Fingerprint() != b.Fingerprint(), "Should change with the elements")
+//	}
+func (s *Set[T]) Fingerprint() uint64 {
+	return internal.Fingerprint(s.All())
+}
@@ -0,0 +1,32 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/orderedset"
+)
+
+func TestFingerprint(t *testing.T) {
+	tests := []struct {
+		a, b  []int
+		equal bool
+	}{
+		{a: []int{}, b: []int{}, equal: true},
+		{a: []int{1, 2, 3}, b: []int{3, 1, 2}, equal: true},
+		{a: []int{1, 2, 3}, b: []int{1, 2}, equal: false},
+		{a: []int{1, 2}, b: []int{1, 3}, equal: false},
+		{a: []int{0}, b: []int{}, equal: false},
+	}
+
+	for i, test := range tests {
+		a, b := set.FromSlice(test.a), set.FromSlice(test.b)
+		if got := a.Fingerprint() == b.Fingerprint(); got != test.equal {
+			t.Fatalf("Index: %d, Expected: %v, Got: %v", i, test.equal, got)
+		}
+
+		if a.Fingerprint() != orderedset.FromSlice(test.a).Fingerprint() {
+			t.Fatalf("Index: %d, Expected: same fingerprint as the OrderedSet", i)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package set
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Jamlie/set/internal"
+)
+
+// A `Flag` is a command-line flag whose values are collected into a Set.
+//
+// It implements `flag.Value` and `flag.Getter`, as well as the `Type` method
+// required by pflag. The flag can be repeated (`--tag=a --tag=b`) and every
+// occurrence may hold comma-separated values (`--tag=a,b`). Passing a value
+// that was already given, or a value that cannot be parsed, is reported as an
+// error through the flag package.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"flag"
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		exclude := set.NewFlag(func(s string) (string, error) {
+//			return s, nil
+//		})
+//		flag.Var(exclude, "exclude", "paths to exclude, can be repeated")
+//		flag.Parse()
+//
+//		// --exclude=a,b --exclude=c
+//		fmt.Println(exclude.Values()) // [a b c]
+//	}
+type Flag[T comparable] struct {
+	set   *Set[T]
+	parse func(string) (T, error)
+}
+
+// Create a new Flag that uses `parse` to convert every value given on the
+// command line into T.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"flag"
+//		"strconv"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		ports := set.NewFlag(strconv.Atoi)
+//		flag.Var(ports, "port", "ports to listen on")
+//		flag.Parse()
+//	}
+func NewFlag[T comparable](parse func(string) (T, error)) *Flag[T] {
+	return &Flag[T]{
+		set:   New[T](),
+		parse: parse,
+	}
+}
+
+// Returns the set holding the values collected so far.
+func (f *Flag[T]) Values() *Set[T] {
+	if f.set == nil {
+		f.set = New[T]()
+	}
+
+	return f.set
+}
+
+// Parses a single occurrence of the flag, which may hold several
+// comma-separated values, and inserts them into the set.
+//
+// It is called by the flag package. No value is inserted if any of them
+// fails to parse or is a duplicate.
+func (f *Flag[T]) Set(value string) error {
+	s := f.Values()
+	parsed := New[T]()
+
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		k, err := f.parse(raw)
+		if err != nil {
+			return fmt.Errorf("invalid value %q: %w", raw, err)
+		}
+
+		if s.Contains(k) || parsed.Contains(k) {
+			return fmt.Errorf("duplicate value %q", raw)
+		}
+
+		parsed.Insert(k)
+	}
+
+	s.InsertSeq(parsed.All())
+	return nil
+}
+
+// Returns the values of the flag joined by commas, in an arbitrary order.
+func (f *Flag[T]) String() string {
+	if f == nil || f.set == nil {
+		return ""
+	}
+
+	values := make([]string, 0, f.set.Len())
+	for k := range f.set.All() {
+		values = append(values, fmt.Sprint(k))
+	}
+
+	return strings.Join(values, ",")
+}
+
+// Returns the underlying `*Set[T]`, implementing `flag.Getter`.
+func (f *Flag[T]) Get() any {
+	return f.Values()
+}
+
+// Returns the name of the value type, as required by pflag's `Value` interface.
+//
+// Sets of basic types are named like pflag's own flags, such as `intSet` or `stringSet`,
+// and sets of any other type are named `set`.
+func (f *Flag[T]) Type() string {
+	return internal.FlagType[T]()
+}
@@ -0,0 +1,73 @@
+package set_test
+
+import (
+	"flag"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Jamlie/set"
+)
+
+func TestFlagParse(t *testing.T) {
+	test := struct {
+		args   []string
+		expect []int
+	}{
+		args:   []string{"-port=80,443", "-port", "8080"},
+		expect: []int{80, 443, 8080},
+	}
+
+	ports := set.NewFlag(strconv.Atoi)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(ports, "port", "")
+
+	if err := fs.Parse(test.args); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	if !sameSlice(ports.Values().Keys(), test.expect) {
+		t.Fatalf("Expected: %v, Got: %s", test.expect, ports.Values())
+	}
+}
+
+func TestFlagErrors(t *testing.T) {
+	tests := []struct {
+		args []string
+	}{
+		{args: []string{"-port=80,80"}},
+		{args: []string{"-port=80", "-port=80"}},
+		{args: []string{"-port=http"}},
+	}
+
+	for i, test := range tests {
+		ports := set.NewFlag(strconv.Atoi)
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(ports, "port", "")
+
+		if err := fs.Parse(test.args); err == nil {
+			t.Fatalf("Index: %d, Expected: error, Got: %s", i, ports.Values())
+		}
+	}
+}
+
+func TestFlagType(t *testing.T) {
+	tests := []struct {
+		flag   interface{ Type() string }
+		expect string
+	}{
+		{flag: set.NewFlag(strconv.Atoi), expect: "intSet"},
+		{flag: set.NewFlag(func(s string) (string, error) { return s, nil }), expect: "stringSet"},
+		{flag: set.NewFlag(time.ParseDuration), expect: "durationSet"},
+		{flag: set.NewFlag(func(s string) (any, error) { return s, nil }), expect: "set"},
+		{flag: set.NewFlag(func(s string) (struct{ X, Y int }, error) { return struct{ X, Y int }{}, nil }), expect: "set"},
+	}
+
+	for i, test := range tests {
+		if got := test.flag.Type(); got != test.expect {
+			t.Fatalf("Index: %d, Expected: %s, Got: %s", i, test.expect, got)
+		}
+	}
+}
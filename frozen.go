@@ -0,0 +1,239 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+
+	"github.com/Jamlie/set/internal"
+)
+
+// A `Frozen` is a read-only Set.
+//
+// It is created with `Set.Freeze` and exposes no method that mutates it, so it can
+// be shared between goroutines and read concurrently without any locking. Operations
+// that combine sets return new Frozen sets, and `Thaw` returns a mutable copy.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.New[string]()
+//		v.Insert("admin")
+//		v.Insert("owner")
+//
+//		roles := v.Freeze()
+//		v.Insert("guest") // roles is not affected
+//
+//		go func() {
+//			fmt.Println(roles.Contains("admin"))
+//		}()
+//		fmt.Println(roles.Len()) // 2
+//	}
+type Frozen[T comparable] struct {
+	set map[T]struct{}
+}
+
+// Returns a read-only copy of the set.
+//
+// Later changes to the set are not visible through the Frozen set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.New[int]()
+//		v.Insert(1)
+//		frozen := v.Freeze()
+//		v.Insert(2)
+//		assert.Assert(frozen.Len() == 1, "Should not see later changes")
+//	}
+func (s *Set[T]) Freeze() *Frozen[T] {
+	return &Frozen[T]{
+		set: maps.Clone(s.set),
+	}
+}
+
+// Returns a mutable copy of the frozen set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		frozen := set.FromSlice([]int{1, 2}).Freeze()
+//		v := frozen.Thaw()
+//		v.Insert(3)
+//		assert.Assert(frozen.Len() == 2, "Should not change the frozen set")
+//	}
+func (f *Frozen[T]) Thaw() *Set[T] {
+	s := WithCapacity[T](f.Len())
+	maps.Copy(s.set, f.set)
+	return s
+}
+
+// The number of elements the set has.
+func (f *Frozen[T]) Len() int {
+	return len(f.set)
+}
+
+// Returns `true` if the set contains a value.
+func (f *Frozen[T]) Contains(k T) bool {
+	_, ok := f.set[k]
+	return ok
+}
+
+// Returns `true` if the set contains no elements.
+func (f *Frozen[T]) Empty() bool {
+	return f.Len() == 0
+}
+
+// Returns a slice containing the keys of the set in an arbitrary order.
+func (f *Frozen[T]) Keys() []T {
+	keys := make([]T, 0, f.Len())
+
+	for k := range f.set {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// Returns a stringified version of the set with elements in an arbitrary order
+func (f Frozen[T]) String() string {
+	return fmt.Sprint(f.Keys())
+}
+
+// A way to iterate through the Frozen set using a range-loop
+func (f *Frozen[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range f.set {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a new set with the elements that are in either set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		a := set.FromSlice([]int{1, 2}).Freeze()
+//		b := set.FromSlice([]int{2, 3}).Freeze()
+//		fmt.Println(a.Union(b)) // [1 2 3]
+//	}
+func (f *Frozen[T]) Union(other *Frozen[T]) *Frozen[T] {
+	return frozen(internal.Union[T](f, other))
+}
+
+// Returns a new set with the elements that are in both sets.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		a := set.FromSlice([]int{1, 2}).Freeze()
+//		b := set.FromSlice([]int{2, 3}).Freeze()
+//		fmt.Println(a.Intersection(b)) // [2]
+//	}
+func (f *Frozen[T]) Intersection(other *Frozen[T]) *Frozen[T] {
+	return frozen(internal.Intersection[T](f, other))
+}
+
+// Returns a new set with the elements that are in this set but not in `other`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		a := set.FromSlice([]int{1, 2}).Freeze()
+//		b := set.FromSlice([]int{2, 3}).Freeze()
+//		fmt.Println(a.Difference(b)) // [1]
+//	}
+func (f *Frozen[T]) Difference(other *Frozen[T]) *Frozen[T] {
+	return frozen(internal.Difference[T](f, other))
+}
+
+// Returns a new set with the elements that are in either set, but not in both.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		a := set.FromSlice([]int{1, 2}).Freeze()
+//		b := set.FromSlice([]int{2, 3}).Freeze()
+//		fmt.Println(a.SymmetricDifference(b)) // [1 3]
+//	}
+func (f *Frozen[T]) SymmetricDifference(other *Frozen[T]) *Frozen[T] {
+	return frozen(internal.SymmetricDifference[T](f, other))
+}
+
+// Returns `true` if every element of this set is in `other`.
+func (f *Frozen[T]) IsSubset(other *Frozen[T]) bool {
+	return internal.IsSubset[T](f, other)
+}
+
+// Returns `true` if both sets contain the same elements.
+func (f *Frozen[T]) Equal(other *Frozen[T]) bool {
+	return internal.Equal[T](f, other)
+}
+
+func frozen[T comparable](seq iter.Seq[T]) *Frozen[T] {
+	result := make(map[T]struct{})
+	for k := range seq {
+		result[k] = struct{}{}
+	}
+
+	return &Frozen[T]{
+		set: result,
+	}
+}
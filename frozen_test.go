@@ -0,0 +1,71 @@
+package set_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Jamlie/set"
+)
+
+func TestFreeze(t *testing.T) {
+	s := set.FromSlice([]int{1, 2, 3})
+	frozen := s.Freeze()
+	s.Insert(4)
+
+	if frozen.Len() != 3 || frozen.Contains(4) {
+		t.Fatalf("Expected: %v, Got: %s", []int{1, 2, 3}, frozen)
+	}
+
+	thawed := frozen.Thaw()
+	thawed.Delete(1)
+	if !frozen.Contains(1) {
+		t.Fatalf("Expected: %v, Got: %s", []int{1, 2, 3}, frozen)
+	}
+}
+
+func TestFrozenAlgebra(t *testing.T) {
+	a := set.FromSlice([]int{1, 2, 3, 4}).Freeze()
+	b := set.FromSlice([]int{3, 4, 5}).Freeze()
+
+	tests := []struct {
+		name   string
+		result *set.Frozen[int]
+		expect []int
+	}{
+		{name: "union", result: a.Union(b), expect: []int{1, 2, 3, 4, 5}},
+		{name: "intersection", result: a.Intersection(b), expect: []int{3, 4}},
+		{name: "difference", result: a.Difference(b), expect: []int{1, 2}},
+		{name: "symmetric difference", result: a.SymmetricDifference(b), expect: []int{1, 2, 5}},
+	}
+
+	for _, test := range tests {
+		if !sameSlice(test.result.Keys(), test.expect) {
+			t.Fatalf("%s: Expected: %v, Got: %s", test.name, test.expect, test.result)
+		}
+	}
+
+	if !a.Intersection(b).IsSubset(a) || a.IsSubset(b) {
+		t.Fatalf("Expected: intersection to be a subset")
+	}
+
+	if !a.Equal(a.Union(a)) || a.Equal(b) {
+		t.Fatalf("Expected: equal sets to compare equal")
+	}
+}
+
+func TestFrozenConcurrentReads(t *testing.T) {
+	frozen := set.FromSlice([]int{1, 2, 3}).Freeze()
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := range frozen.All() {
+				_ = frozen.Contains(k)
+			}
+			_ = frozen.Union(frozen)
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,318 @@
+// Package hashset provides a generic implementation of a set with custom equality.
+//
+// A HashSet is a collection of unique elements, where a Hasher decides which elements
+// are the same. This lets it hold types that are not comparable, such as structs with
+// slices or maps, and types whose equality is semantic, such as case-insensitive strings.
+// It has the same API as `set.Set`.
+package hashset
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+	"slices"
+)
+
+// A `HashSet` is implemented as a `map[uint64][]T` from hashes to the elements having them.
+//
+// Unlike a `set.Set`, T can be any type, as long as the Hasher gives equal elements the
+// same hash. When an element equal to one already in the set is inserted, the set keeps
+// the element it already has.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/hashset"
+//	)
+//
+//	func main() {
+//		tags := hashset.New[string](hashset.FoldedStrings{})
+//		tags.Insert("Go")
+//		tags.Insert("go")
+//		tags.Insert("GO")
+//
+//		fmt.Println(tags.Len())          // 1
+//		fmt.Println(tags.Contains("gO")) // true
+//		fmt.Println(tags)                // [Go]
+//
+//		blobs := hashset.New[[]byte](hashset.Bytes{})
+//		blobs.Insert([]byte("payload"))
+//		fmt.Println(blobs.Contains([]byte("payload"))) // true
+//	}
+type HashSet[T any] struct {
+	hasher  Hasher[T]
+	seed    maphash.Seed
+	buckets map[uint64][]T
+	len     int
+}
+
+// Create a new instance of HashSet using `hasher`, with Go's default capacity.
+//
+// Examples:
+//
+//	package main
+//
+//	import "github.com/Jamlie/set/hashset"
+//
+//	func main() {
+//		v := hashset.New[[]byte](hashset.Bytes{})
+//		_ = v
+//	}
+func New[T any](hasher Hasher[T]) *HashSet[T] {
+	return &HashSet[T]{
+		hasher:  hasher,
+		seed:    maphash.MakeSeed(),
+		buckets: make(map[uint64][]T),
+	}
+}
+
+// Create a new instance of HashSet using `hasher`, with a specified capacity
+//
+// The set will be able to hold at least `capacity` without reallocating
+// until it's full. This function will panic if capacity is negative.
+//
+// Examples:
+//
+//	package main
+//
+//	import "github.com/Jamlie/set/hashset"
+//
+//	func main() {
+//		v := hashset.WithCapacity[string](hashset.FoldedStrings{}, 10)
+//		_ = v
+//	}
+func WithCapacity[T any](hasher Hasher[T], capacity int) *HashSet[T] {
+	if capacity < 0 {
+		panic("Cannot allocate with a negative capacity")
+	}
+
+	return &HashSet[T]{
+		hasher:  hasher,
+		seed:    maphash.MakeSeed(),
+		buckets: make(map[uint64][]T, capacity),
+	}
+}
+
+// find returns the hash of `k` and its index in its bucket, or -1.
+func (s *HashSet[T]) find(k T) (uint64, int) {
+	h := s.hasher.Hash(s.seed, k)
+	for i, item := range s.buckets[h] {
+		if s.hasher.Equal(item, k) {
+			return h, i
+		}
+	}
+
+	return h, -1
+}
+
+// Adds a value to the set.
+//
+// Inserting a value equal to one in the set won't change the set
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/hashset"
+//	)
+//
+//	func main() {
+//		v := hashset.New[string](hashset.FoldedStrings{})
+//		v.Insert("a")
+//		v.Insert("A")
+//		assert.Assert(v.Len() == 1, "Should not insert an equal value more than once")
+//	}
+func (s *HashSet[T]) Insert(k T) {
+	h, i := s.find(k)
+	if i < 0 {
+		s.buckets[h] = append(s.buckets[h], k)
+		s.len++
+	}
+}
+
+// Removes the value equal to `k` from the set.
+//
+// Removing a value that does not exists will result in nothing.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/hashset"
+//	)
+//
+//	func main() {
+//		v := hashset.New[string](hashset.FoldedStrings{})
+//		v.Insert("a")
+//		v.Insert("b")
+//		v.Delete("A")
+//		v.Delete("c")
+//		assert.Assert(v.Len() == 1, "Delete should remove the value if exists")
+//	}
+func (s *HashSet[T]) Delete(k T) {
+	h, i := s.find(k)
+	if i < 0 {
+		return
+	}
+
+	if bucket := s.buckets[h]; len(bucket) == 1 {
+		delete(s.buckets, h)
+	} else {
+		s.buckets[h] = slices.Delete(bucket, i, i+1)
+	}
+	s.len--
+}
+
+// The number of elements the set currently has.
+func (s *HashSet[T]) Len() int {
+	return s.len
+}
+
+// Returns `true` if the set contains a value equal to `k`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/hashset"
+//	)
+//
+//	func main() {
+//		v := hashset.New[[]byte](hashset.Bytes{})
+//		v.Insert([]byte{1, 2})
+//		assert.Assert(v.Contains([]byte{1, 2}), "Equal slice exists")
+//		assert.Assert(!v.Contains([]byte{2, 1}), "Different slice doesn't exist")
+//	}
+func (s *HashSet[T]) Contains(k T) bool {
+	_, i := s.find(k)
+	return i >= 0
+}
+
+// Returns a new set with the same elements and Hasher.
+//
+// The elements themselves are not copied, so a slice in both sets is shared.
+func (s *HashSet[T]) Clone() *HashSet[T] {
+	c := &HashSet[T]{
+		hasher:  s.hasher,
+		seed:    s.seed,
+		buckets: make(map[uint64][]T, len(s.buckets)),
+		len:     s.len,
+	}
+
+	for h, bucket := range s.buckets {
+		c.buckets[h] = slices.Clone(bucket)
+	}
+
+	return c
+}
+
+// Returns a slice containing the elements of the set in an arbitrary order.
+func (s *HashSet[T]) Keys() []T {
+	keys := make([]T, 0, s.len)
+
+	for _, bucket := range s.buckets {
+		keys = append(keys, bucket...)
+	}
+
+	return keys
+}
+
+// Clears the set, removing all values.
+func (s *HashSet[T]) Clear() {
+	clear(s.buckets)
+	s.len = 0
+}
+
+// Returns `true` if the set contains no elements.
+func (s *HashSet[T]) Empty() bool {
+	return s.len == 0
+}
+
+// Returns a stringified version of the set with elements in an arbitrary order
+func (s HashSet[T]) String() string {
+	return fmt.Sprint(s.Keys())
+}
+
+// A way to iterate through HashSet using a range-loop
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"log"
+//
+//		"github.com/Jamlie/set/hashset"
+//	)
+//
+//	func main() {
+//		v := hashset.FromSlice(hashset.FoldedStrings{}, []string{"a", "B"})
+//
+//		for k := range v.All() {
+//			log.Println(k)
+//		}
+//	}
+func (s *HashSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, bucket := range s.buckets {
+			for _, k := range bucket {
+				if !yield(k) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Collect allows passing any `iter.Seq[T]` and replaces all values in the existing set.
+// Note: Collect changes the whole set.
+func (s *HashSet[T]) Collect(seq iter.Seq[T]) {
+	newSet := WithCapacity(s.hasher, s.len)
+	newSet.seed = s.seed
+	newSet.InsertSeq(seq)
+	*s = *newSet
+}
+
+// InsertSeq allows entering any `iter.Seq[T]` and appends all values into the existing set.
+func (s *HashSet[T]) InsertSeq(seq iter.Seq[T]) {
+	for k := range seq {
+		s.Insert(k)
+	}
+}
+
+// Converts a slice into a set, using `hasher`
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/hashset"
+//	)
+//
+//	func main() {
+//		v := hashset.FromSlice(hashset.FoldedStrings{}, []string{"first", "First", "last"})
+//
+//		fmt.Println(v) // [first last]
+//	}
+func FromSlice[Slice ~[]T, T any](hasher Hasher[T], v Slice) *HashSet[T] {
+	s := WithCapacity(hasher, len(v))
+
+	for _, k := range v {
+		s.Insert(k)
+	}
+
+	return s
+}
@@ -0,0 +1,47 @@
+package hashset
+
+import "github.com/Jamlie/set/internal"
+
+type hashSetIter[T any] struct {
+	set         *HashSet[T]
+	internalSet *HashSet[T]
+}
+
+func (s *HashSet[T]) Iter() *hashSetIter[T] {
+	return &hashSetIter[T]{
+		set:         s,
+		internalSet: s,
+	}
+}
+
+func (it *hashSetIter[T]) Map(fn internal.MapIterFn[T]) *hashSetIter[T] {
+	newSet := WithCapacity(it.internalSet.hasher, it.internalSet.Len())
+	for k := range it.internalSet.All() {
+		newSet.Insert(fn(k))
+	}
+
+	*it.internalSet = *newSet
+	return it.internalSet.Iter()
+}
+
+func (it *hashSetIter[T]) Filter(fn internal.FilterIterFn[T]) *hashSetIter[T] {
+	newSet := New(it.internalSet.hasher)
+	for k := range it.internalSet.All() {
+		if fn(k) {
+			newSet.Insert(k)
+		}
+	}
+
+	*it.internalSet = *newSet
+	return it.internalSet.Iter()
+}
+
+func (it *hashSetIter[T]) ForEach(fn internal.ForEachIterFn[T]) {
+	for k := range it.internalSet.All() {
+		fn(k)
+	}
+}
+
+func (it *hashSetIter[T]) Collect() {
+	*it.set = *it.internalSet
+}
@@ -0,0 +1,87 @@
+package hashset_test
+
+import (
+	"hash/maphash"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Jamlie/set/hashset"
+)
+
+type route struct {
+	Method string
+	Path   []string
+}
+
+var routes = hashset.HasherFuncs(
+	func(seed maphash.Seed, r route) uint64 {
+		return maphash.String(seed, r.Method+" "+strings.Join(r.Path, "/"))
+	},
+	func(a, b route) bool {
+		return a.Method == b.Method && slices.Equal(a.Path, b.Path)
+	},
+)
+
+func TestHashSet(t *testing.T) {
+	s := hashset.New(routes)
+
+	tests := []struct {
+		route route
+		len   int
+	}{
+		{route: route{"GET", []string{"users"}}, len: 1},
+		{route: route{"GET", []string{"users"}}, len: 1},
+		{route: route{"POST", []string{"users"}}, len: 2},
+		{route: route{"GET", []string{"users", "id"}}, len: 3},
+	}
+
+	for i, test := range tests {
+		s.Insert(test.route)
+		if s.Len() != test.len || !s.Contains(test.route) {
+			t.Fatalf("Index: %d, Expected: %d elements, Got: %s", i, test.len, s)
+		}
+	}
+
+	clone := s.Clone()
+	s.Delete(route{"GET", []string{"users"}})
+	if s.Len() != 2 || s.Contains(route{"GET", []string{"users"}}) {
+		t.Fatalf("Expected: GET users deleted, Got: %s", s)
+	}
+	if clone.Len() != 3 {
+		t.Fatalf("Expected: clone unchanged, Got: %s", clone)
+	}
+
+	s.Iter().Filter(func(r route) bool {
+		return r.Method == "GET"
+	}).Collect()
+	if s.Len() != 1 || !s.Contains(route{"GET", []string{"users", "id"}}) {
+		t.Fatalf("Expected: [GET users/id], Got: %s", s)
+	}
+
+	s.Clear()
+	if !s.Empty() || s.Contains(route{"GET", []string{"users", "id"}}) {
+		t.Fatalf("Expected: empty set, Got: %s", s)
+	}
+}
+
+func TestHashSetKeepsFirst(t *testing.T) {
+	s := hashset.FromSlice(hashset.FoldedStrings{}, []string{"Go", "go", "GO", "Rust"})
+
+	keys := s.Keys()
+	slices.Sort(keys)
+	if !slices.Equal(keys, []string{"Go", "Rust"}) {
+		t.Fatalf("Expected: [Go Rust], Got: %v", keys)
+	}
+
+	s.Iter().Map(strings.ToUpper).Collect()
+	if !s.Contains("go") || !s.Contains("rust") || s.Len() != 2 {
+		t.Fatalf("Expected: [GO RUST], Got: %s", s)
+	}
+
+	// as with set.Set, Map and Filter change the set without calling Collect
+	s.Iter().Filter(func(k string) bool { return k != "GO" })
+	if s.Contains("go") || s.Len() != 1 {
+		t.Fatalf("Expected: [RUST], Got: %s", s)
+	}
+}
@@ -0,0 +1,150 @@
+package hashset
+
+import (
+	"bytes"
+	"hash/maphash"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// A `Hasher` decides which elements of a HashSet are the same.
+//
+// Equal elements must have the same hash for the same seed. The seed is chosen by each
+// HashSet, and should be passed on to `hash/maphash` so the hashes can't be predicted.
+type Hasher[T any] interface {
+	Hash(seed maphash.Seed, k T) uint64
+	Equal(a, b T) bool
+}
+
+type hasherFuncs[T any] struct {
+	hash  func(maphash.Seed, T) uint64
+	equal func(a, b T) bool
+}
+
+func (h hasherFuncs[T]) Hash(seed maphash.Seed, k T) uint64 {
+	return h.hash(seed, k)
+}
+
+func (h hasherFuncs[T]) Equal(a, b T) bool {
+	return h.equal(a, b)
+}
+
+// Returns a Hasher made of a hash and an equality function.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"hash/maphash"
+//		"slices"
+//
+//		"github.com/Jamlie/set/hashset"
+//	)
+//
+//	type Route struct {
+//		Method string
+//		Path   []string
+//	}
+//
+//	func main() {
+//		routes := hashset.New(hashset.HasherFuncs(
+//			func(seed maphash.Seed, r Route) uint64 {
+//				var h maphash.Hash
+//				h.SetSeed(seed)
+//				h.WriteString(r.Method)
+//				for _, p := range r.Path {
+//					h.WriteString(p)
+//					h.WriteByte('/')
+//				}
+//				return h.Sum64()
+//			},
+//			func(a, b Route) bool {
+//				return a.Method == b.Method && slices.Equal(a.Path, b.Path)
+//			},
+//		))
+//		routes.Insert(Route{"GET", []string{"users"}})
+//	}
+func HasherFuncs[T any](hash func(seed maphash.Seed, k T) uint64, equal func(a, b T) bool) Hasher[T] {
+	return hasherFuncs[T]{
+		hash:  hash,
+		equal: equal,
+	}
+}
+
+// A `Bytes` hasher compares byte slices by their content.
+type Bytes struct{}
+
+func (Bytes) Hash(seed maphash.Seed, k []byte) uint64 {
+	return maphash.Bytes(seed, k)
+}
+
+func (Bytes) Equal(a, b []byte) bool {
+	return bytes.Equal(a, b)
+}
+
+// A `FoldedStrings` hasher compares strings regardless of case, the same way as
+// `strings.EqualFold`.
+type FoldedStrings struct{}
+
+func (FoldedStrings) Hash(seed maphash.Seed, k string) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+
+	var buf [utf8.UTFMax]byte
+	for _, r := range k {
+		h.Write(utf8.AppendRune(buf[:0], fold(r)))
+	}
+
+	return h.Sum64()
+}
+
+func (FoldedStrings) Equal(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
+
+// fold returns the smallest rune that is equal to `r` under simple case folding.
+func fold(r rune) rune {
+	smallest := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		smallest = min(smallest, f)
+	}
+
+	return smallest
+}
+
+// A `NormalizedStrings` hasher compares strings after normalizing them.
+//
+// Normalize is usually a Unicode normalization form from golang.org/x/text/unicode/norm,
+// such as `norm.NFC.String`, but can be any function, like one that cleans up URLs.
+// It's called on every hash and comparison.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/hashset"
+//		"golang.org/x/text/unicode/norm"
+//	)
+//
+//	func main() {
+//		names := hashset.New[string](hashset.NormalizedStrings{Normalize: norm.NFC.String})
+//		names.Insert("café")
+//		names.Insert("café")
+//		fmt.Println(names.Len()) // 1
+//	}
+type NormalizedStrings struct {
+	Normalize func(string) string
+}
+
+func (n NormalizedStrings) Hash(seed maphash.Seed, k string) uint64 {
+	return maphash.String(seed, n.Normalize(k))
+}
+
+func (n NormalizedStrings) Equal(a, b string) bool {
+	return n.Normalize(a) == n.Normalize(b)
+}
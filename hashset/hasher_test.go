@@ -0,0 +1,66 @@
+package hashset_test
+
+import (
+	"hash/maphash"
+	"strings"
+	"testing"
+
+	"github.com/Jamlie/set/hashset"
+)
+
+func TestHashers(t *testing.T) {
+	seed := maphash.MakeSeed()
+	folded := hashset.FoldedStrings{}
+	normalized := hashset.NormalizedStrings{Normalize: func(s string) string {
+		return strings.TrimSuffix(strings.ToLower(s), "/")
+	}}
+
+	tests := []struct {
+		name  string
+		a, b  string
+		equal func(a, b string) bool
+		hash  func(k string) uint64
+	}{
+		{
+			name:  "FoldedStrings",
+			a:     "Straße ΣΊΣΥΦΟΣ",
+			b:     "STRAßE σίσυφος",
+			equal: folded.Equal,
+			hash:  func(k string) uint64 { return folded.Hash(seed, k) },
+		},
+		{
+			name:  "FoldedStrings Kelvin",
+			a:     "K",
+			b:     "k",
+			equal: folded.Equal,
+			hash:  func(k string) uint64 { return folded.Hash(seed, k) },
+		},
+		{
+			name:  "NormalizedStrings",
+			a:     "https://Example.com/",
+			b:     "https://example.com",
+			equal: normalized.Equal,
+			hash:  func(k string) uint64 { return normalized.Hash(seed, k) },
+		},
+		{
+			name:  "Bytes",
+			a:     "payload",
+			b:     "payload",
+			equal: func(a, b string) bool { return hashset.Bytes{}.Equal([]byte(a), []byte(b)) },
+			hash:  func(k string) uint64 { return hashset.Bytes{}.Hash(seed, []byte(k)) },
+		},
+	}
+
+	for _, test := range tests {
+		if !test.equal(test.a, test.b) {
+			t.Fatalf("%s, Expected: %q equal to %q", test.name, test.a, test.b)
+		}
+		if test.hash(test.a) != test.hash(test.b) {
+			t.Fatalf("%s, Expected: same hash for %q and %q", test.name, test.a, test.b)
+		}
+	}
+
+	if folded.Equal("a", "b") || folded.Hash(seed, "a") == folded.Hash(seed, "b") {
+		t.Fatalf("Expected: a and b to differ")
+	}
+}
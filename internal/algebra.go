@@ -0,0 +1,87 @@
+package internal
+
+import "iter"
+
+// Set is what the set algebra helpers need from a set.
+type Set[T any] interface {
+	All() iter.Seq[T]
+	Contains(k T) bool
+	Len() int
+}
+
+// Union yields the elements of `a` followed by the elements of `b` that are not in `a`.
+func Union[T any](a, b Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range a.All() {
+			if !yield(k) {
+				return
+			}
+		}
+
+		for k := range Difference(b, a) {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Intersection yields the elements of `a` that are in `b`, in the order of `a`.
+func Intersection[T any](a, b Set[T]) iter.Seq[T] {
+	return filter(a, b.Contains)
+}
+
+// Difference yields the elements of `a` that are not in `b`, in the order of `a`.
+func Difference[T any](a, b Set[T]) iter.Seq[T] {
+	return filter(a, func(k T) bool {
+		return !b.Contains(k)
+	})
+}
+
+// SymmetricDifference yields the elements of `a` that are not in `b`, followed by the
+// elements of `b` that are not in `a`.
+func SymmetricDifference[T any](a, b Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range Difference(a, b) {
+			if !yield(k) {
+				return
+			}
+		}
+
+		for k := range Difference(b, a) {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// IsSubset reports whether every element of `a` is in `b`.
+func IsSubset[T any](a, b Set[T]) bool {
+	if a.Len() > b.Len() {
+		return false
+	}
+
+	for k := range a.All() {
+		if !b.Contains(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equal reports whether `a` and `b` hold the same elements.
+func Equal[T any](a, b Set[T]) bool {
+	return a.Len() == b.Len() && IsSubset(a, b)
+}
+
+func filter[T any](s Set[T], keep func(k T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range s.All() {
+			if keep(k) && !yield(k) {
+				return
+			}
+		}
+	}
+}
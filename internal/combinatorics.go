@@ -0,0 +1,188 @@
+package internal
+
+import "iter"
+
+// Combinations yields the k-element combinations of the indices 0 to n-1 in
+// lexicographic order. The yielded slice is reused.
+func Combinations(n, k int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		if k < 0 || k > n {
+			return
+		}
+
+		idx := make([]int, k)
+		for i := range idx {
+			idx[i] = i
+		}
+
+		for {
+			if !yield(idx) {
+				return
+			}
+
+			// Find the rightmost index that can still move right.
+			i := k - 1
+			for i >= 0 && idx[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+
+			idx[i]++
+			for j := i + 1; j < k; j++ {
+				idx[j] = idx[j-1] + 1
+			}
+		}
+	}
+}
+
+// Permutations yields the permutations of the indices 0 to n-1 in lexicographic order.
+// The yielded slice is reused.
+func Permutations(n int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+
+		for {
+			if !yield(idx) {
+				return
+			}
+
+			i := n - 2
+			for i >= 0 && idx[i] > idx[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+
+			j := n - 1
+			for idx[j] < idx[i] {
+				j--
+			}
+			idx[i], idx[j] = idx[j], idx[i]
+
+			for l, r := i+1, n-1; l < r; l, r = l+1, r-1 {
+				idx[l], idx[r] = idx[r], idx[l]
+			}
+		}
+	}
+}
+
+// Product yields every tuple of indices where the i-th index is below sizes[i], with
+// the last index changing fastest. The yielded slice is reused.
+func Product(sizes []int) iter.Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for _, n := range sizes {
+			if n == 0 {
+				return
+			}
+		}
+
+		idx := make([]int, len(sizes))
+		for {
+			if !yield(idx) {
+				return
+			}
+
+			i := len(idx) - 1
+			for i >= 0 && idx[i] == sizes[i]-1 {
+				idx[i] = 0
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			idx[i]++
+		}
+	}
+}
+
+// PowerSetInto yields the subsets of the keys by increasing size, each built in `buf`.
+// The keys are read when the iteration starts.
+func PowerSetInto[T any](keys func() []T, buf []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		keys := keys()
+		for k := range len(keys) + 1 {
+			for idx := range Combinations(len(keys), k) {
+				if buf = pick(buf, keys, idx); !yield(buf) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// CombinationsInto yields the k-element combinations of the keys, each built in `buf`.
+// The keys are read when the iteration starts.
+func CombinationsInto[T any](keys func() []T, k int, buf []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		keys := keys()
+		for idx := range Combinations(len(keys), k) {
+			if buf = pick(buf, keys, idx); !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+// PermutationsInto yields the permutations of the keys, each built in `buf`.
+// The keys are read when the iteration starts.
+func PermutationsInto[T any](keys func() []T, buf []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		keys := keys()
+		for idx := range Permutations(len(keys)) {
+			if buf = pick(buf, keys, idx); !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+// ProductInto yields every tuple taking the i-th element from the keys of sets[i], each
+// built in `buf`. The keys are read when the iteration starts.
+func ProductInto[S, T any](sets []S, keys func(S) []T, buf []T) iter.Seq[[]T] {
+	return func(yield func([]T) bool) {
+		all := make([][]T, len(sets))
+		sizes := make([]int, len(sets))
+		for i, s := range sets {
+			all[i] = keys(s)
+			sizes[i] = len(all[i])
+		}
+
+		for idx := range Product(sizes) {
+			buf = buf[:0]
+			for i, j := range idx {
+				buf = append(buf, all[i][j])
+			}
+
+			if !yield(buf) {
+				return
+			}
+		}
+	}
+}
+
+// Collect yields `fn` of every slice of `seq`, so that reused slices can be turned into
+// slices or sets the caller may keep.
+func Collect[T, S any](seq iter.Seq[[]T], fn func([]T) S) iter.Seq[S] {
+	return func(yield func(S) bool) {
+		for keys := range seq {
+			if !yield(fn(keys)) {
+				return
+			}
+		}
+	}
+}
+
+func pick[T any](buf []T, keys []T, idx []int) []T {
+	buf = buf[:0]
+	for _, i := range idx {
+		buf = append(buf, keys[i])
+	}
+
+	return buf
+}
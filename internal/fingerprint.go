@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"hash/maphash"
+	"iter"
+)
+
+// seed is shared by every set type, so equal contents fingerprint the same in all of them.
+var seed = maphash.MakeSeed()
+
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// Fingerprint hashes the elements of `seq` independently of their order, by adding up
+// their mixed hashes. The elements must be unique.
+func Fingerprint[T comparable](seq iter.Seq[T]) uint64 {
+	var sum, n uint64
+	for k := range seq {
+		sum += mix(maphash.Comparable(seed, k))
+		n++
+	}
+
+	return mix(sum ^ mix(n))
+}
+
+// OrderedFingerprint hashes the elements of `seq` in order.
+func OrderedFingerprint[T comparable](seq iter.Seq[T]) uint64 {
+	var h maphash.Hash
+	h.SetSeed(seed)
+	for k := range seq {
+		maphash.WriteComparable(&h, k)
+	}
+
+	return h.Sum64()
+}
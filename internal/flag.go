@@ -0,0 +1,20 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+)
+
+// FlagType names a flag holding a set of T for pflag's help output, like pflag's own
+// `stringSlice` and `intSlice`. Element types other than the basic ones are named `set`.
+func FlagType[T any]() string {
+	var zero T
+	switch any(zero).(type) {
+	case string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%TSet", zero)
+	case time.Duration:
+		return "durationSet"
+	default:
+		return "set"
+	}
+}
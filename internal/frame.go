@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// MaxFrame bounds the size of a frame read from a peer.
+const MaxFrame = 1 << 28
+
+var ErrFrameTooLarge = errors.New("frame: message is too large")
+
+// Exchange sends `out` as a length-prefixed frame while reading the frame of the peer,
+// so both sides can send first without blocking on unbuffered connections such as
+// `net.Pipe`.
+func Exchange(rw io.ReadWriter, out []byte) ([]byte, error) {
+	errc := make(chan error, 1)
+	go func() {
+		header := binary.BigEndian.AppendUint32(nil, uint32(len(out)))
+		_, err := rw.Write(append(header, out...))
+		errc <- err
+	}()
+
+	in, err := ReadFrame(rw)
+	if werr := <-errc; err == nil {
+		err = werr
+	}
+
+	return in, err
+}
+
+func ReadFrame(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	n := binary.BigEndian.Uint32(header[:])
+	if n > MaxFrame {
+		return nil, ErrFrameTooLarge
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
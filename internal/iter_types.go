@@ -1,7 +1,7 @@
 package internal
 
 type (
-	MapIterFn[T comparable]     func(k T) T
-	FilterIterFn[T comparable]  func(k T) bool
-	ForEachIterFn[T comparable] func(k T)
+	MapIterFn[T any]     func(k T) T
+	FilterIterFn[T any]  func(k T) bool
+	ForEachIterFn[T any] func(k T)
 )
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+)
+
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Protobuf wire types.
+const (
+	WireVarint  = 0
+	WireFixed64 = 1
+	WireBytes   = 2
+	WireFixed32 = 5
+)
+
+const maxFieldNumber = 1<<29 - 1
+
+var (
+	ErrTruncated = errors.New("protowire: truncated message")
+	ErrOverflow  = errors.New("protowire: varint overflows 64 bits")
+)
+
+func AppendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+func SizeVarint(v uint64) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
+
+func AppendTag(b []byte, num, typ int) []byte {
+	if num < 1 || num > maxFieldNumber {
+		panic(fmt.Sprintf("protowire: invalid field number %d", num))
+	}
+	return AppendVarint(b, uint64(num)<<3|uint64(typ))
+}
+
+func ConsumeVarint(b []byte) (uint64, int, error) {
+	var v uint64
+	for i := 0; i < len(b); i++ {
+		if i == 9 && b[i] > 1 {
+			return 0, 0, ErrOverflow
+		}
+		v |= uint64(b[i]&0x7f) << (7 * i)
+		if b[i] < 0x80 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, ErrTruncated
+}
+
+func ConsumeBytes(b []byte) ([]byte, int, error) {
+	l, n, err := ConsumeVarint(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	if l > uint64(len(b)-n) {
+		return nil, 0, ErrTruncated
+	}
+	return b[n : n+int(l)], n + int(l), nil
+}
+
+// ConsumeField reads a single field and returns its number, wire type,
+// the raw value (the payload for length-delimited fields) and the number
+// of bytes read.
+func ConsumeField(b []byte) (num, typ int, value []byte, n int, err error) {
+	tag, n, err := ConsumeVarint(b)
+	if err != nil {
+		return 0, 0, nil, 0, err
+	}
+
+	num, typ = int(tag>>3), int(tag&7)
+	if num < 1 || num > maxFieldNumber {
+		return 0, 0, nil, 0, fmt.Errorf("protowire: invalid field number %d", num)
+	}
+
+	var m int
+	switch typ {
+	case WireVarint:
+		_, m, err = ConsumeVarint(b[n:])
+		value = b[n : n+m]
+	case WireFixed64, WireFixed32:
+		m = 8
+		if typ == WireFixed32 {
+			m = 4
+		}
+		if len(b)-n < m {
+			return 0, 0, nil, 0, ErrTruncated
+		}
+		value = b[n : n+m]
+	case WireBytes:
+		value, m, err = ConsumeBytes(b[n:])
+	default:
+		return 0, 0, nil, 0, fmt.Errorf("protowire: unsupported wire type %d", typ)
+	}
+	if err != nil {
+		return 0, 0, nil, 0, err
+	}
+
+	return num, typ, value, n + m, nil
+}
@@ -0,0 +1,332 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+
+	"github.com/Jamlie/set/internal"
+)
+
+// A `Policy` decides what inserting a value does when the set already has a value with
+// the same key.
+type Policy int
+
+const (
+	// KeepExisting leaves the value already in the set, the same as a Set does.
+	KeepExisting Policy = iota
+	// ReplaceExisting stores the inserted value instead of the one in the set.
+	ReplaceExisting
+)
+
+// A `KeyedSet` is a set of values that are unique by a key derived from them.
+//
+// It's implemented as a `map[K]V`, where the key of a value is given by a function, so
+// V can be any type, including structs that are not comparable. Set operations compare
+// keys only.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	type Person struct {
+//		Id   int
+//		Name string
+//		Age  int
+//	}
+//
+//	func main() {
+//		people := set.NewKeyed(func(p Person) int { return p.Id }, set.ReplaceExisting)
+//		people.Insert(Person{Id: 21, Name: "John", Age: 30})
+//		people.Insert(Person{Id: 22, Name: "Jane", Age: 30})
+//		people.Insert(Person{Id: 21, Name: "John", Age: 31})
+//
+//		fmt.Println(people.Len()) // 2
+//		john, _ := people.Get(21)
+//		fmt.Println(john.Age) // 31
+//	}
+type KeyedSet[K comparable, V any] struct {
+	key    func(V) K
+	policy Policy
+	values map[K]V
+}
+
+// Create a new empty KeyedSet where the key of a value is `key(value)`, and inserting a
+// value whose key is already in the set follows `policy`.
+func NewKeyed[K comparable, V any](key func(V) K, policy Policy) *KeyedSet[K, V] {
+	return &KeyedSet[K, V]{
+		key:    key,
+		policy: policy,
+		values: make(map[K]V),
+	}
+}
+
+// Adds a value to the set, following the set's Policy if a value with the same key is
+// already there.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.NewKeyed(func(s string) int { return len(s) }, set.KeepExisting)
+//		v.Insert("ab")
+//		v.Insert("cd")
+//		first, _ := v.Get(2)
+//		assert.Assert(first == "ab", "Should keep the existing value")
+//	}
+func (s *KeyedSet[K, V]) Insert(v V) {
+	k := s.key(v)
+	if _, exists := s.values[k]; !exists || s.policy == ReplaceExisting {
+		s.values[k] = v
+	}
+}
+
+// InsertSeq allows entering any `iter.Seq[V]` and inserts all values into the existing
+// set, following the set's Policy.
+func (s *KeyedSet[K, V]) InsertSeq(seq iter.Seq[V]) {
+	for v := range seq {
+		s.Insert(v)
+	}
+}
+
+// Returns the value whose key is `k`.
+func (s *KeyedSet[K, V]) Get(k K) (V, bool) {
+	v, ok := s.values[k]
+	return v, ok
+}
+
+// Removes the value whose key is `k`.
+//
+// Removing a key that does not exists will result in nothing.
+func (s *KeyedSet[K, V]) DeleteKey(k K) {
+	delete(s.values, k)
+}
+
+// Removes the value with the same key as `v`.
+func (s *KeyedSet[K, V]) Delete(v V) {
+	delete(s.values, s.key(v))
+}
+
+// Returns `true` if the set contains a value whose key is `k`.
+func (s *KeyedSet[K, V]) ContainsKey(k K) bool {
+	_, ok := s.values[k]
+	return ok
+}
+
+// Returns `true` if the set contains a value with the same key as `v`.
+func (s *KeyedSet[K, V]) Contains(v V) bool {
+	return s.ContainsKey(s.key(v))
+}
+
+// The number of values the set has.
+func (s *KeyedSet[K, V]) Len() int {
+	return len(s.values)
+}
+
+// Returns `true` if the set contains no values.
+func (s *KeyedSet[K, V]) Empty() bool {
+	return len(s.values) == 0
+}
+
+// Clears the set, removing all values.
+func (s *KeyedSet[K, V]) Clear() {
+	clear(s.values)
+}
+
+// Returns a copy of the set, with the same key function and Policy.
+func (s *KeyedSet[K, V]) Clone() *KeyedSet[K, V] {
+	return &KeyedSet[K, V]{
+		key:    s.key,
+		policy: s.policy,
+		values: maps.Clone(s.values),
+	}
+}
+
+// Returns a slice containing the keys of the values in an arbitrary order.
+func (s *KeyedSet[K, V]) Keys() []K {
+	keys := make([]K, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// Returns a Set of the keys of the values.
+func (s *KeyedSet[K, V]) KeySet() *Set[K] {
+	keys := WithCapacity[K](len(s.values))
+	for k := range s.values {
+		keys.set[k] = struct{}{}
+	}
+
+	return keys
+}
+
+// Returns a slice containing the values of the set in an arbitrary order.
+func (s *KeyedSet[K, V]) Values() []V {
+	values := make([]V, 0, len(s.values))
+	for _, v := range s.values {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+// Returns a stringified version of the values of the set in an arbitrary order
+func (s KeyedSet[K, V]) String() string {
+	return fmt.Sprint(s.Values())
+}
+
+// A way to iterate through the keys and values of the set using a range-loop
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"log"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.NewKeyed(func(s string) byte { return s[0] }, set.KeepExisting)
+//		v.Insert("apple")
+//		v.Insert("banana")
+//
+//		for k, value := range v.All() {
+//			log.Println(string(k), value)
+//		}
+//	}
+func (s *KeyedSet[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s.values {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a new set with the values whose key is in either set. When both sets have a
+// value for a key, the value of this set is kept.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		byLen := func(s string) int { return len(s) }
+//		a := set.NewKeyed(byLen, set.KeepExisting)
+//		a.Insert("a")
+//		a.Insert("bb")
+//		b := set.NewKeyed(byLen, set.KeepExisting)
+//		b.Insert("cc")
+//		b.Insert("ddd")
+//
+//		fmt.Println(a.Union(b).Len()) // 3
+//	}
+func (s *KeyedSet[K, V]) Union(other *KeyedSet[K, V]) *KeyedSet[K, V] {
+	return s.pick(internal.Union[K](keyView[K, V]{s}, keyView[K, V]{other}), other)
+}
+
+// Returns a new set with the values of this set whose key is also in `other`.
+func (s *KeyedSet[K, V]) Intersection(other *KeyedSet[K, V]) *KeyedSet[K, V] {
+	return s.pick(internal.Intersection[K](keyView[K, V]{s}, keyView[K, V]{other}), other)
+}
+
+// Returns a new set with the values of this set whose key is not in `other`.
+func (s *KeyedSet[K, V]) Difference(other *KeyedSet[K, V]) *KeyedSet[K, V] {
+	return s.pick(internal.Difference[K](keyView[K, V]{s}, keyView[K, V]{other}), other)
+}
+
+// Returns a new set with the values whose key is in either set, but not in both.
+func (s *KeyedSet[K, V]) SymmetricDifference(other *KeyedSet[K, V]) *KeyedSet[K, V] {
+	return s.pick(internal.SymmetricDifference[K](keyView[K, V]{s}, keyView[K, V]{other}), other)
+}
+
+// Returns `true` if every key of this set is in `other`.
+func (s *KeyedSet[K, V]) IsSubset(other *KeyedSet[K, V]) bool {
+	return internal.IsSubset[K](keyView[K, V]{s}, keyView[K, V]{other})
+}
+
+// Returns `true` if both sets have the same keys, regardless of their values.
+func (s *KeyedSet[K, V]) Equal(other *KeyedSet[K, V]) bool {
+	return internal.Equal[K](keyView[K, V]{s}, keyView[K, V]{other})
+}
+
+// pick returns a new set with the values of the keys of `seq`, taken from this set if
+// it has them and from `other` otherwise.
+func (s *KeyedSet[K, V]) pick(seq iter.Seq[K], other *KeyedSet[K, V]) *KeyedSet[K, V] {
+	result := NewKeyed(s.key, s.policy)
+	for k := range seq {
+		v, ok := s.values[k]
+		if !ok {
+			v = other.values[k]
+		}
+		result.values[k] = v
+	}
+
+	return result
+}
+
+// keyView exposes the keys of a KeyedSet to the set algebra helpers.
+type keyView[K comparable, V any] struct {
+	s *KeyedSet[K, V]
+}
+
+func (v keyView[K, V]) All() iter.Seq[K] {
+	return maps.Keys(v.s.values)
+}
+
+func (v keyView[K, V]) Contains(k K) bool {
+	return v.s.ContainsKey(k)
+}
+
+func (v keyView[K, V]) Len() int {
+	return v.s.Len()
+}
+
+// Converts a slice into a KeyedSet, following `policy` for values with the same key.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		words := []string{"go", "rust", "zig", "lua"}
+//		v := set.KeyedFromSlice(words, func(s string) int { return len(s) }, set.KeepExisting)
+//		fmt.Println(v.Len()) // 3
+//	}
+func KeyedFromSlice[Slice ~[]V, K comparable, V any](v Slice, key func(V) K, policy Policy) *KeyedSet[K, V] {
+	s := NewKeyed(key, policy)
+	for _, value := range v {
+		s.Insert(value)
+	}
+
+	return s
+}
@@ -0,0 +1,85 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/Jamlie/set"
+)
+
+type person struct {
+	Id   int
+	Name string
+	Tags []string
+}
+
+func personId(p person) int {
+	return p.Id
+}
+
+func TestKeyedSetPolicy(t *testing.T) {
+	tests := []struct {
+		policy set.Policy
+		expect string
+	}{
+		{policy: set.KeepExisting, expect: "John"},
+		{policy: set.ReplaceExisting, expect: "Johnny"},
+	}
+
+	for i, test := range tests {
+		people := set.NewKeyed(personId, test.policy)
+		people.Insert(person{Id: 1, Name: "John", Tags: []string{"admin"}})
+		people.Insert(person{Id: 2, Name: "Jane"})
+		people.Insert(person{Id: 1, Name: "Johnny"})
+
+		if people.Len() != 2 {
+			t.Fatalf("Index: %d, Expected: 2, Got: %d", i, people.Len())
+		}
+
+		if p, ok := people.Get(1); !ok || p.Name != test.expect {
+			t.Fatalf("Index: %d, Expected: %s, Got: %v", i, test.expect, p)
+		}
+	}
+}
+
+func TestKeyedSetAlgebra(t *testing.T) {
+	a := set.KeyedFromSlice([]person{{Id: 1, Name: "a1"}, {Id: 2, Name: "a2"}, {Id: 3, Name: "a3"}}, personId, set.KeepExisting)
+	b := set.KeyedFromSlice([]person{{Id: 2, Name: "b2"}, {Id: 4, Name: "b4"}}, personId, set.KeepExisting)
+
+	tests := []struct {
+		name   string
+		result *set.KeyedSet[int, person]
+		expect []int
+	}{
+		{name: "Union", result: a.Union(b), expect: []int{1, 2, 3, 4}},
+		{name: "Intersection", result: a.Intersection(b), expect: []int{2}},
+		{name: "Difference", result: a.Difference(b), expect: []int{1, 3}},
+		{name: "SymmetricDifference", result: a.SymmetricDifference(b), expect: []int{1, 3, 4}},
+	}
+
+	for _, test := range tests {
+		if !sameSlice(test.result.Keys(), test.expect) || !sameSlice(test.result.KeySet().Keys(), test.expect) {
+			t.Fatalf("%s, Expected: %v, Got: %v", test.name, test.expect, test.result.Keys())
+		}
+	}
+
+	if p, _ := a.Union(b).Get(2); p.Name != "a2" {
+		t.Fatalf("Expected: a2, Got: %s", p.Name)
+	}
+
+	a.DeleteKey(1)
+	a.Delete(person{Id: 3})
+	if !a.IsSubset(b) || a.Equal(b) || a.ContainsKey(1) {
+		t.Fatalf("Expected: [2] subset of [2 4], Got: %s", a)
+	}
+
+	count := 0
+	for k, p := range b.All() {
+		if k != p.Id {
+			t.Fatalf("Expected: key %d, Got: %d", p.Id, k)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Fatalf("Expected: 2, Got: %d", count)
+	}
+}
@@ -0,0 +1,45 @@
+package merkle
+
+import (
+	"bytes"
+	"encoding/gob"
+)
+
+// A `Codec` converts elements to bytes and back.
+//
+// The bytes are hashed to place elements in the digest and sent to the peer during a
+// sync, so equal elements must always encode to the same bytes.
+type Codec[T any] interface {
+	Encode(k T) ([]byte, error)
+	Decode(b []byte) (T, error)
+}
+
+// A `StringCodec` encodes strings as their bytes.
+type StringCodec[T ~string] struct{}
+
+func (StringCodec[T]) Encode(k T) ([]byte, error) {
+	return []byte(k), nil
+}
+
+func (StringCodec[T]) Decode(b []byte) (T, error) {
+	return T(b), nil
+}
+
+// A `GobCodec` encodes elements with encoding/gob, which works for any type gob supports
+// but makes every element carry its type description.
+type GobCodec[T any] struct{}
+
+func (GobCodec[T]) Encode(k T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(k); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (GobCodec[T]) Decode(b []byte) (T, error) {
+	var k T
+	err := gob.NewDecoder(bytes.NewReader(b)).Decode(&k)
+	return k, err
+}
@@ -0,0 +1,186 @@
+// Package merkle provides a Merkle tree digest of a set, and an anti-entropy protocol
+// that uses it to sync two replicas.
+//
+// Elements are placed in buckets by the prefix of their hash, the buckets are the leaves
+// of a tree with 16 children per node, and every node hashes its children. Replicas
+// compare the roots of their trees and only descend into the nodes that differ, so a
+// sync sends an amount of data that depends on the differences rather than on the size
+// of the sets.
+package merkle
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// Hash is the digest of a node of the tree.
+type Hash [sha256.Size]byte
+
+// fanout is the number of children of every node, 4 bits of the element hash per level.
+const fanout = 16
+
+// MaxDepth is the deepest tree a Digest can have.
+const MaxDepth = 6
+
+// A `Digest` is a Merkle tree of the hashes of a set's elements, updated incrementally
+// as elements are added and removed.
+//
+// A Digest does not hold the elements, so it relies on its caller to only add elements
+// that are not in it and only remove elements that are. `Tracked` does so for a set.
+// A Digest is not safe for concurrent use.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/merkle"
+//	)
+//
+//	func main() {
+//		a := merkle.NewDigest[string](merkle.StringCodec[string]{}, 2)
+//		b := merkle.NewDigest[string](merkle.StringCodec[string]{}, 2)
+//		a.Add("x")
+//		a.Add("y")
+//		b.Add("y")
+//		b.Add("x")
+//
+//		fmt.Println(a.Root() == b.Root()) // true
+//	}
+type Digest[T any] struct {
+	codec Codec[T]
+	depth int
+
+	// counts and sums hold the number and the XOR of the element hashes of each leaf.
+	counts []uint64
+	sums   []Hash
+
+	// nodes[l] holds the hashes of the nodes at level l, the root being level 0 and the
+	// leaves level depth; stale[l] marks the ones to recompute.
+	nodes [][]Hash
+	stale [][]bool
+}
+
+// Create a new empty Digest with `depth` levels below the root, so 16^depth buckets.
+//
+// This function will panic if depth is not between 1 and MaxDepth.
+func NewDigest[T any](codec Codec[T], depth int) *Digest[T] {
+	if depth < 1 || depth > MaxDepth {
+		panic(fmt.Sprintf("Cannot create a digest with a depth outside of 1 to %d", MaxDepth))
+	}
+
+	d := &Digest[T]{
+		codec:  codec,
+		depth:  depth,
+		nodes:  make([][]Hash, depth+1),
+		stale:  make([][]bool, depth+1),
+		counts: make([]uint64, 1<<(4*depth)),
+		sums:   make([]Hash, 1<<(4*depth)),
+	}
+
+	for l := range d.nodes {
+		d.nodes[l] = make([]Hash, 1<<(4*l))
+		d.stale[l] = make([]bool, 1<<(4*l))
+		for i := range d.stale[l] {
+			d.stale[l][i] = true
+		}
+	}
+
+	return d
+}
+
+// The number of levels below the root.
+func (d *Digest[T]) Depth() int {
+	return d.depth
+}
+
+// locate returns the hash of `k` and the leaf it belongs to.
+func (d *Digest[T]) locate(k T) (Hash, int, error) {
+	b, err := d.codec.Encode(k)
+	if err != nil {
+		return Hash{}, 0, err
+	}
+
+	h, leaf := d.place(b)
+	return h, leaf, nil
+}
+
+// place returns the hash of an encoded element and the leaf it belongs to.
+func (d *Digest[T]) place(b []byte) (Hash, int) {
+	h := Hash(sha256.Sum256(b))
+	return h, int(binary.BigEndian.Uint64(h[:]) >> (64 - 4*d.depth))
+}
+
+func (d *Digest[T]) update(k T, add bool) error {
+	h, leaf, err := d.locate(k)
+	if err != nil {
+		return err
+	}
+
+	if add {
+		d.counts[leaf]++
+	} else {
+		d.counts[leaf]--
+	}
+	for i := range h {
+		d.sums[leaf][i] ^= h[i]
+	}
+
+	for l, i := d.depth, leaf; l >= 0; l, i = l-1, i/fanout {
+		d.stale[l][i] = true
+	}
+
+	return nil
+}
+
+// Adds an element that is not in the digest yet.
+//
+// Returns an error if the element can't be encoded.
+func (d *Digest[T]) Add(k T) error {
+	return d.update(k, true)
+}
+
+// Removes an element that is in the digest.
+//
+// Returns an error if the element can't be encoded.
+func (d *Digest[T]) Remove(k T) error {
+	return d.update(k, false)
+}
+
+// Returns the hash of the `i`th node at `level`, 0 being the root and Depth the buckets.
+//
+// This function will panic if there's no such node.
+func (d *Digest[T]) Node(level, i int) Hash {
+	if !d.stale[level][i] {
+		return d.nodes[level][i]
+	}
+
+	h := sha256.New()
+	if level == d.depth {
+		h.Write(binary.BigEndian.AppendUint64(nil, d.counts[i]))
+		h.Write(d.sums[i][:])
+	} else {
+		for c := i * fanout; c < (i+1)*fanout; c++ {
+			child := d.Node(level+1, c)
+			h.Write(child[:])
+		}
+	}
+
+	h.Sum(d.nodes[level][i][:0])
+	d.stale[level][i] = false
+	return d.nodes[level][i]
+}
+
+// Returns the hash of the whole tree.
+func (d *Digest[T]) Root() Hash {
+	return d.Node(0, 0)
+}
+
+// Returns the bucket `k` belongs to, an index at level Depth.
+func (d *Digest[T]) Bucket(k T) (int, error) {
+	_, leaf, err := d.locate(k)
+	return leaf, err
+}
@@ -0,0 +1,43 @@
+package merkle_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Jamlie/set/merkle"
+)
+
+func TestDigestIncremental(t *testing.T) {
+	codec := merkle.StringCodec[string]{}
+	a := merkle.NewDigest[string](codec, 2)
+	b := merkle.NewDigest[string](codec, 2)
+
+	for i := range 1_000 {
+		a.Add(fmt.Sprint(i))
+	}
+	empty := a.Root()
+
+	for i := 999; i >= 500; i-- {
+		b.Add(fmt.Sprint(i))
+	}
+	if a.Root() == b.Root() {
+		t.Fatalf("Expected: different roots, Got: %x", a.Root())
+	}
+
+	for i := range 500 {
+		b.Add(fmt.Sprint(i))
+	}
+	if a.Root() != b.Root() {
+		t.Fatalf("Expected: %x, Got: %x", a.Root(), b.Root())
+	}
+
+	a.Add("extra")
+	if a.Root() == empty {
+		t.Fatalf("Expected: root to change, Got: %x", a.Root())
+	}
+
+	a.Remove("extra")
+	if a.Root() != empty {
+		t.Fatalf("Expected: %x, Got: %x", empty, a.Root())
+	}
+}
@@ -0,0 +1,300 @@
+package merkle
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"iter"
+	"sync"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/internal"
+)
+
+var (
+	// ErrDepthMismatch is returned when syncing with a replica whose digest has a
+	// different depth.
+	ErrDepthMismatch = errors.New("merkle: the digests have different depths")
+	// ErrProtocol is returned when the peer sends a message that doesn't follow the protocol.
+	ErrProtocol = errors.New("merkle: unexpected message from peer")
+)
+
+// A `Replica` is a set whose digest can be tracked, such as a `set.Set` or a
+// `concurrentset.ConcurrentSet`.
+type Replica[T comparable] interface {
+	Insert(k T)
+	Delete(k T)
+	Contains(k T) bool
+	All() iter.Seq[T]
+}
+
+// A `Tracked` keeps a set and its Digest in step: elements inserted and deleted through
+// it update the digest too.
+//
+// The set should not be modified outside the Tracked, or the digest will no longer
+// match it. A Tracked is safe for concurrent use.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/Jamlie/set"
+//		"github.com/Jamlie/set/merkle"
+//	)
+//
+//	func main() {
+//		t, err := merkle.Track(set.New[string](), merkle.StringCodec[string]{}, 3)
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		t.Insert("a")
+//		fmt.Println(t.Root())
+//	}
+type Tracked[T comparable] struct {
+	mu     sync.Mutex
+	set    Replica[T]
+	digest *Digest[T]
+}
+
+// Builds the digest of `s` and starts tracking it, with a tree of `depth` levels.
+//
+// Returns an error if an element can't be encoded. This function will panic if depth
+// is not between 1 and MaxDepth.
+func Track[T comparable](s Replica[T], codec Codec[T], depth int) (*Tracked[T], error) {
+	digest := NewDigest(codec, depth)
+	for k := range s.All() {
+		if err := digest.Add(k); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Tracked[T]{
+		set:    s,
+		digest: digest,
+	}, nil
+}
+
+// Adds a value to the set and the digest.
+//
+// Returns an error, leaving the set unchanged, if the value can't be encoded.
+func (t *Tracked[T]) Insert(k T) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.insert(k)
+}
+
+func (t *Tracked[T]) insert(k T) error {
+	if t.set.Contains(k) {
+		return nil
+	}
+
+	if err := t.digest.Add(k); err != nil {
+		return err
+	}
+
+	t.set.Insert(k)
+	return nil
+}
+
+// Removes a value from the set and the digest.
+//
+// Returns an error, leaving the set unchanged, if the value can't be encoded.
+func (t *Tracked[T]) Delete(k T) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.set.Contains(k) {
+		return nil
+	}
+
+	if err := t.digest.Remove(k); err != nil {
+		return err
+	}
+
+	t.set.Delete(k)
+	return nil
+}
+
+// Returns `true` if the set contains a value.
+func (t *Tracked[T]) Contains(k T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.set.Contains(k)
+}
+
+// Returns the root hash of the digest; two sets with the same elements have the same root.
+func (t *Tracked[T]) Root() Hash {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.digest.Root()
+}
+
+// children appends the hashes of the children of the nodes `parents` at `level`.
+func (t *Tracked[T]) children(b []byte, level int, parents []int) []byte {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, p := range parents {
+		for c := p * fanout; c < (p+1)*fanout; c++ {
+			h := t.digest.Node(level+1, c)
+			b = append(b, h[:]...)
+		}
+	}
+
+	return b
+}
+
+// bucketed encodes the elements that belong to `buckets`.
+func (t *Tracked[T]) bucketed(buckets []int) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	wanted := set.FromSlice(buckets)
+	var elements [][]byte
+	for k := range t.set.All() {
+		b, err := t.digest.codec.Encode(k)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, leaf := t.digest.place(b); wanted.Contains(leaf) {
+			elements = append(elements, b)
+		}
+	}
+
+	b := binary.AppendUvarint(nil, uint64(len(elements)))
+	for _, e := range elements {
+		b = binary.AppendUvarint(b, uint64(len(e)))
+		b = append(b, e...)
+	}
+
+	return b, nil
+}
+
+// Syncs the set with the replica on the other end of `rw`, which must call Sync at
+// the same time, so that both end up with the union of their elements.
+//
+// The replicas compare the roots of their digests and only exchange the hashes of the
+// subtrees that differ, then the elements of the buckets that differ. Deletions are not
+// synced: an element deleted on one replica comes back from the other. Changes made
+// while syncing are picked up by the next sync.
+//
+// Returns the elements received from the other replica that were not in the set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"net"
+//
+//		"github.com/Jamlie/set"
+//		"github.com/Jamlie/set/merkle"
+//	)
+//
+//	func main() {
+//		codec := merkle.StringCodec[string]{}
+//		a, _ := merkle.Track(set.FromSlice([]string{"x", "y"}), codec, 3)
+//		b, _ := merkle.Track(set.FromSlice([]string{"y", "z"}), codec, 3)
+//
+//		left, right := net.Pipe()
+//		go merkle.Sync(right, b)
+//
+//		received, err := merkle.Sync(left, a)
+//		fmt.Println(received, err) // [z] <nil>
+//	}
+func Sync[T comparable](rw io.ReadWriter, t *Tracked[T]) (*set.Set[T], error) {
+	root := t.Root()
+	in, err := internal.Exchange(rw, append([]byte{byte(t.digest.depth)}, root[:]...))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(in) != 1+len(root) {
+		return nil, ErrProtocol
+	}
+	if int(in[0]) != t.digest.depth {
+		return nil, ErrDepthMismatch
+	}
+
+	received := set.New[T]()
+	if Hash(in[1:]) == root {
+		return received, nil
+	}
+
+	frontier := []int{0}
+	for level := 0; level < t.digest.depth && len(frontier) > 0; level++ {
+		ours := t.children(nil, level, frontier)
+		theirs, err := internal.Exchange(rw, ours)
+		if err != nil {
+			return nil, err
+		}
+		if len(theirs) != len(ours) {
+			return nil, ErrProtocol
+		}
+
+		next := make([]int, 0, len(frontier))
+		for i, p := range frontier {
+			for c := range fanout {
+				off := (i*fanout + c) * len(Hash{})
+				if Hash(ours[off:]) != Hash(theirs[off:]) {
+					next = append(next, p*fanout+c)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	if len(frontier) == 0 {
+		return received, nil
+	}
+
+	ours, err := t.bucketed(frontier)
+	if err != nil {
+		return nil, err
+	}
+
+	if in, err = internal.Exchange(rw, ours); err != nil {
+		return nil, err
+	}
+
+	n, m := binary.Uvarint(in)
+	if m <= 0 || n > uint64(len(in)) {
+		return nil, ErrProtocol
+	}
+	in = in[m:]
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for range n {
+		l, m := binary.Uvarint(in)
+		if m <= 0 || l > uint64(len(in)-m) {
+			return received, ErrProtocol
+		}
+
+		k, err := t.digest.codec.Decode(in[m : m+int(l)])
+		if err != nil {
+			return received, err
+		}
+		in = in[m+int(l):]
+
+		if !t.set.Contains(k) {
+			if err := t.insert(k); err != nil {
+				return received, err
+			}
+			received.Insert(k)
+		}
+	}
+
+	return received, nil
+}
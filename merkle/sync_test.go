@@ -0,0 +1,94 @@
+package merkle_test
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/concurrentset"
+	"github.com/Jamlie/set/merkle"
+)
+
+type result[T comparable] struct {
+	received *set.Set[T]
+	err      error
+}
+
+func syncPipe[T comparable](a, b *merkle.Tracked[T]) (result[T], result[T]) {
+	left, right := net.Pipe()
+	defer left.Close()
+	defer right.Close()
+
+	done := make(chan result[T])
+	go func() {
+		received, err := merkle.Sync(right, b)
+		done <- result[T]{received, err}
+	}()
+
+	received, err := merkle.Sync(left, a)
+	return result[T]{received, err}, <-done
+}
+
+type point struct {
+	X, Y int
+}
+
+func TestSync(t *testing.T) {
+	codec := merkle.GobCodec[point]{}
+
+	plain := set.New[point]()
+	concurrent := concurrentset.New[point]()
+	for i := range 2_000 {
+		plain.Insert(point{i, i})
+		concurrent.Insert(point{i + 3, i + 3})
+	}
+
+	a, err := merkle.Track[point](plain, codec, 3)
+	if err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+	b, err := merkle.Track[point](concurrent, codec, 3)
+	if err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	ra, rb := syncPipe(a, b)
+	if ra.err != nil || rb.err != nil {
+		t.Fatalf("Expected: no error, Got: %v, %v", ra.err, rb.err)
+	}
+
+	if ra.received.Len() != 3 || !ra.received.Contains(point{2002, 2002}) {
+		t.Fatalf("Expected: the 3 last points, Got: %s", ra.received)
+	}
+	if rb.received.Len() != 3 || !rb.received.Contains(point{0, 0}) {
+		t.Fatalf("Expected: the 3 first points, Got: %s", rb.received)
+	}
+
+	if a.Root() != b.Root() || plain.Len() != 2_003 || concurrent.Len() != 2_003 {
+		t.Fatalf("Expected: synced replicas, Got: %d and %d elements", plain.Len(), concurrent.Len())
+	}
+
+	b.Delete(point{0, 0})
+	b.Insert(point{-1, -1})
+	ra, rb = syncPipe(a, b)
+	if ra.err != nil || rb.err != nil || a.Root() != b.Root() {
+		t.Fatalf("Expected: synced replicas, Got: %v, %v", ra.err, rb.err)
+	}
+
+	ra, rb = syncPipe(a, b)
+	if ra.received.Len() != 0 || rb.received.Len() != 0 {
+		t.Fatalf("Expected: nothing to sync, Got: %s and %s", ra.received, rb.received)
+	}
+}
+
+func TestSyncDepthMismatch(t *testing.T) {
+	codec := merkle.StringCodec[string]{}
+	a, _ := merkle.Track[string](set.FromSlice([]string{"a"}), codec, 2)
+	b, _ := merkle.Track[string](set.FromSlice([]string{"b"}), codec, 3)
+
+	ra, rb := syncPipe(a, b)
+	if !errors.Is(ra.err, merkle.ErrDepthMismatch) || !errors.Is(rb.err, merkle.ErrDepthMismatch) {
+		t.Fatalf("Expected: %v, Got: %v, %v", merkle.ErrDepthMismatch, ra.err, rb.err)
+	}
+}
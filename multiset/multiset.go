@@ -0,0 +1,426 @@
+// Package multiset provides a generic implementation of a multiset, also known as a bag.
+//
+// A Multiset is a collection where elements can appear more than once, implemented as
+// a map from each element to the number of times it appears.
+// The Multiset is parameterized with a type T, which must be comparable.
+package multiset
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+
+	"github.com/Jamlie/set"
+)
+
+// A `Multiset` is implemented as a `map[T]int` of positive counts.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		tags := multiset.FromSlice([]string{"go", "db", "go", "api", "go"})
+//		tags.Add("db", 1)
+//		tags.Remove("api", 1)
+//
+//		fmt.Println(tags.Count("go")) // 3
+//		fmt.Println(tags.Len()) // 5
+//		fmt.Println(tags.Distinct()) // 2
+//		fmt.Println(tags.MostCommon(1)) // [{go 3}]
+//	}
+type Multiset[T comparable] struct {
+	counts map[T]int
+	len    int
+}
+
+// An `Entry` is an element of a Multiset with the number of times it appears.
+type Entry[T comparable] struct {
+	Value T
+	Count int
+}
+
+// Create a new empty Multiset with Go's default capacity.
+//
+// Examples:
+//
+//	package main
+//
+//	import "github.com/Jamlie/set/multiset"
+//
+//	func main() {
+//		v := multiset.New[string]()
+//		_ = v
+//	}
+func New[T comparable]() *Multiset[T] {
+	return &Multiset[T]{
+		counts: make(map[T]int),
+	}
+}
+
+// Create a new empty Multiset able to hold at least `capacity` distinct elements
+// without reallocating. This function will panic if capacity is negative.
+func WithCapacity[T comparable](capacity int) *Multiset[T] {
+	if capacity < 0 {
+		panic("Cannot allocate with a negative capacity")
+	}
+
+	return &Multiset[T]{
+		counts: make(map[T]int, capacity),
+	}
+}
+
+// Adds `n` occurrences of `v`. This function will panic if n is negative.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		v := multiset.New[string]()
+//		v.Add("apple", 3)
+//		v.Add("apple", 2)
+//		assert.Assert(v.Count("apple") == 5, "Should add up the occurrences")
+//	}
+func (m *Multiset[T]) Add(v T, n int) {
+	if n < 0 {
+		panic("Cannot add a negative number of occurrences")
+	}
+
+	if n > 0 {
+		m.counts[v] += n
+		m.len += n
+	}
+}
+
+// Removes up to `n` occurrences of `v`, and returns how many were removed. This
+// function will panic if n is negative.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		v := multiset.New[string]()
+//		v.Add("apple", 3)
+//		assert.Assert(v.Remove("apple", 5) == 3, "Should remove the occurrences there are")
+//		assert.Assert(!v.Contains("apple"), "Should have no apples left")
+//	}
+func (m *Multiset[T]) Remove(v T, n int) int {
+	if n < 0 {
+		panic("Cannot remove a negative number of occurrences")
+	}
+
+	count := m.counts[v]
+	removed := min(n, count)
+	if removed == count {
+		delete(m.counts, v)
+	} else {
+		m.counts[v] = count - removed
+	}
+	m.len -= removed
+
+	return removed
+}
+
+// Returns the number of times `v` appears, 0 if it doesn't.
+func (m *Multiset[T]) Count(v T) int {
+	return m.counts[v]
+}
+
+// Returns `true` if `v` appears at least once.
+func (m *Multiset[T]) Contains(v T) bool {
+	return m.counts[v] > 0
+}
+
+// The total number of occurrences of all elements.
+func (m *Multiset[T]) Len() int {
+	return m.len
+}
+
+// The number of different elements.
+func (m *Multiset[T]) Distinct() int {
+	return len(m.counts)
+}
+
+// Returns `true` if the multiset contains no elements.
+func (m *Multiset[T]) Empty() bool {
+	return m.len == 0
+}
+
+// Clears the multiset, removing all elements.
+func (m *Multiset[T]) Clear() {
+	clear(m.counts)
+	m.len = 0
+}
+
+// Returns a copy of the multiset.
+func (m *Multiset[T]) Clone() *Multiset[T] {
+	return &Multiset[T]{
+		counts: maps.Clone(m.counts),
+		len:    m.len,
+	}
+}
+
+// Returns the `k` elements that appear the most, from the most common.
+//
+// Elements that appear the same number of times are in an arbitrary order. If k is
+// negative or larger than Distinct, all the elements are returned.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		words := multiset.FromSlice([]string{"a", "b", "a", "c", "a", "b"})
+//		fmt.Println(words.MostCommon(2)) // [{a 3} {b 2}]
+//	}
+func (m *Multiset[T]) MostCommon(k int) []Entry[T] {
+	entries := make([]Entry[T], 0, len(m.counts))
+	for v, n := range m.counts {
+		entries = append(entries, Entry[T]{Value: v, Count: n})
+	}
+
+	slices.SortFunc(entries, func(a, b Entry[T]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	if k >= 0 && k < len(entries) {
+		entries = entries[:k]
+	}
+
+	return entries
+}
+
+// Returns a Set of the distinct elements.
+func (m *Multiset[T]) ToSet() *set.Set[T] {
+	s := set.WithCapacity[T](len(m.counts))
+	for v := range m.counts {
+		s.Insert(v)
+	}
+
+	return s
+}
+
+// Returns a stringified version of the multiset as its elements and their counts
+func (m Multiset[T]) String() string {
+	return fmt.Sprint(m.counts)
+}
+
+// A way to iterate through the distinct elements and their counts using a range-loop
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"log"
+//
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		v := multiset.FromSlice([]string{"a", "b", "a"})
+//
+//		for value, count := range v.All() {
+//			log.Println(value, count)
+//		}
+//	}
+func (m *Multiset[T]) All() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for v, n := range m.counts {
+			if !yield(v, n) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a new multiset where each element appears as many times as it does in the
+// multiset where it appears the most.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		a := multiset.FromSlice([]string{"x", "x", "y"})
+//		b := multiset.FromSlice([]string{"x", "y", "y", "z"})
+//		fmt.Println(a.Union(b)) // map[x:2 y:2 z:1]
+//	}
+func (m *Multiset[T]) Union(other *Multiset[T]) *Multiset[T] {
+	result := m.Clone()
+	for v, n := range other.counts {
+		if n > result.counts[v] {
+			result.len += n - result.counts[v]
+			result.counts[v] = n
+		}
+	}
+
+	return result
+}
+
+// Returns a new multiset where each element appears as many times as it does in the
+// multiset where it appears the least.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		a := multiset.FromSlice([]string{"x", "x", "y"})
+//		b := multiset.FromSlice([]string{"x", "y", "y", "z"})
+//		fmt.Println(a.Intersection(b)) // map[x:1 y:1]
+//	}
+func (m *Multiset[T]) Intersection(other *Multiset[T]) *Multiset[T] {
+	result := New[T]()
+	for v, n := range m.counts {
+		result.Add(v, min(n, other.counts[v]))
+	}
+
+	return result
+}
+
+// Returns a new multiset where the counts of both multisets are added up.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		a := multiset.FromSlice([]string{"x", "x", "y"})
+//		b := multiset.FromSlice([]string{"x", "y", "y", "z"})
+//		fmt.Println(a.Sum(b)) // map[x:3 y:3 z:1]
+//	}
+func (m *Multiset[T]) Sum(other *Multiset[T]) *Multiset[T] {
+	result := m.Clone()
+	for v, n := range other.counts {
+		result.Add(v, n)
+	}
+
+	return result
+}
+
+// Returns a new multiset where the counts of `other` are subtracted, dropping the
+// elements that reach zero.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		a := multiset.FromSlice([]string{"x", "x", "y"})
+//		b := multiset.FromSlice([]string{"x", "y", "y", "z"})
+//		fmt.Println(a.Difference(b)) // map[x:1]
+//	}
+func (m *Multiset[T]) Difference(other *Multiset[T]) *Multiset[T] {
+	result := m.Clone()
+	for v, n := range other.counts {
+		result.Remove(v, n)
+	}
+
+	return result
+}
+
+// Returns `true` if no element appears more times in this multiset than in `other`.
+func (m *Multiset[T]) IsSubset(other *Multiset[T]) bool {
+	if m.len > other.len {
+		return false
+	}
+
+	for v, n := range m.counts {
+		if n > other.counts[v] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns `true` if every element appears as many times in both multisets.
+func (m *Multiset[T]) Equal(other *Multiset[T]) bool {
+	return maps.Equal(m.counts, other.counts)
+}
+
+// Converts a slice into a multiset, counting how many times each element appears
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/multiset"
+//	)
+//
+//	func main() {
+//		v := multiset.FromSlice([]string{"first", "second", "first"})
+//		fmt.Println(v.Count("first")) // 2
+//	}
+func FromSlice[Slice ~[]T, T comparable](v Slice) *Multiset[T] {
+	m := New[T]()
+	for _, k := range v {
+		m.Add(k, 1)
+	}
+
+	return m
+}
+
+// Converts any `iter.Seq[T]` into a multiset, counting how many times each element appears.
+func FromSeq[T comparable](seq iter.Seq[T]) *Multiset[T] {
+	m := New[T]()
+	for k := range seq {
+		m.Add(k, 1)
+	}
+
+	return m
+}
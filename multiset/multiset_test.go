@@ -0,0 +1,93 @@
+package multiset_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/Jamlie/set/multiset"
+)
+
+func TestMultisetCounts(t *testing.T) {
+	m := multiset.FromSlice([]string{"a", "b", "a", "c", "a", "b"})
+
+	tests := []struct {
+		value string
+		count int
+	}{
+		{value: "a", count: 3},
+		{value: "b", count: 2},
+		{value: "c", count: 1},
+		{value: "d", count: 0},
+	}
+
+	for i, test := range tests {
+		if m.Count(test.value) != test.count {
+			t.Fatalf("Index: %d, Expected: %d, Got: %d", i, test.count, m.Count(test.value))
+		}
+	}
+
+	if m.Len() != 6 || m.Distinct() != 3 {
+		t.Fatalf("Expected: 6 and 3, Got: %d and %d", m.Len(), m.Distinct())
+	}
+
+	top := m.MostCommon(2)
+	if len(top) != 2 || top[0] != (multiset.Entry[string]{Value: "a", Count: 3}) || top[1].Value != "b" {
+		t.Fatalf("Expected: [{a 3} {b 2}], Got: %v", top)
+	}
+	if len(m.MostCommon(-1)) != 3 || len(m.MostCommon(10)) != 3 {
+		t.Fatalf("Expected: every element, Got: %v", m.MostCommon(-1))
+	}
+
+	if removed := m.Remove("a", 5); removed != 3 || m.Contains("a") || m.Len() != 3 {
+		t.Fatalf("Expected: 3 removed, Got: %d, %s", removed, m)
+	}
+
+	m.Add("c", 4)
+	if s := m.ToSet(); s.Len() != 2 || !s.Contains("c") {
+		t.Fatalf("Expected: [b c], Got: %s", s)
+	}
+
+	counts := maps.Collect(m.All())
+	if !maps.Equal(counts, map[string]int{"b": 2, "c": 5}) {
+		t.Fatalf("Expected: map[b:2 c:5], Got: %v", counts)
+	}
+}
+
+func TestMultisetAlgebra(t *testing.T) {
+	a := multiset.FromSlice([]string{"x", "x", "y"})
+	b := multiset.FromSlice([]string{"x", "y", "y", "z"})
+
+	tests := []struct {
+		name   string
+		result *multiset.Multiset[string]
+		expect map[string]int
+	}{
+		{name: "Union", result: a.Union(b), expect: map[string]int{"x": 2, "y": 2, "z": 1}},
+		{name: "Intersection", result: a.Intersection(b), expect: map[string]int{"x": 1, "y": 1}},
+		{name: "Sum", result: a.Sum(b), expect: map[string]int{"x": 3, "y": 3, "z": 1}},
+		{name: "Difference", result: a.Difference(b), expect: map[string]int{"x": 1}},
+	}
+
+	for _, test := range tests {
+		got := maps.Collect(test.result.All())
+		if !maps.Equal(got, test.expect) {
+			t.Fatalf("%s, Expected: %v, Got: %v", test.name, test.expect, got)
+		}
+
+		total := 0
+		for _, n := range test.expect {
+			total += n
+		}
+		if test.result.Len() != total {
+			t.Fatalf("%s, Expected: %d, Got: %d", test.name, total, test.result.Len())
+		}
+	}
+
+	if !a.Intersection(b).IsSubset(a) || a.IsSubset(b) {
+		t.Fatalf("Expected: the intersection to be a subset of a, and a not of b")
+	}
+
+	if !a.Sum(b).Difference(b).Equal(a) {
+		t.Fatalf("Expected: (a + b) - b to equal a")
+	}
+}
@@ -0,0 +1,322 @@
+// Package observableset provides a wrapper that reports changes made to a set.
+//
+// An ObservableSet wraps a `set.Set`, an `orderedset.OrderedSet` or a
+// `concurrentset.ConcurrentSet` and emits an Event to its subscribers every time its
+// membership changes. Subscribers are either synchronous callbacks or buffered channels.
+package observableset
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/concurrentset"
+	"github.com/Jamlie/set/orderedset"
+)
+
+// The kind of change an Event reports.
+type EventKind int
+
+const (
+	// Values were inserted, by Insert or InsertSeq.
+	Inserted EventKind = iota
+	// A value was deleted by Delete.
+	Deleted
+	// The set was emptied by Clear.
+	Cleared
+	// The contents of the set were replaced by Collect.
+	Replaced
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case Inserted:
+		return "Inserted"
+	case Deleted:
+		return "Deleted"
+	case Cleared:
+		return "Cleared"
+	case Replaced:
+		return "Replaced"
+	default:
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+}
+
+// An `Event` describes a single change to an ObservableSet.
+//
+// For Inserted it holds the values that were not already in the set, for Deleted and
+// Cleared the values that were removed, and for Replaced the new contents of the set.
+// Operations that don't change the set emit no event, except Collect, which always emits
+// Replaced as it may reorder the set without changing its values. Operations over many
+// values, like InsertSeq or Clear, emit a single event.
+type Event[T comparable] struct {
+	Kind   EventKind
+	Values []T
+}
+
+// The methods an ObservableSet needs from the set it wraps.
+type Set[T comparable] interface {
+	Insert(k T)
+	Delete(k T)
+	Contains(k T) bool
+	Len() int
+	Keys() []T
+	Clear()
+	All() iter.Seq[T]
+}
+
+// An `ObservableSet` wraps a set and emits an Event for every change made through it.
+//
+// Changes made to the wrapped set directly are not reported. Writes through the
+// ObservableSet are serialized, so subscribers see the events in the order the
+// changes happened.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//		"github.com/Jamlie/set/observableset"
+//	)
+//
+//	func main() {
+//		v := observableset.FromSet(set.New[string]())
+//
+//		unsubscribe := v.Subscribe(func(e observableset.Event[string]) {
+//			fmt.Println(e.Kind, e.Values)
+//		})
+//		defer unsubscribe()
+//
+//		v.Insert("a")   // Inserted [a]
+//		v.Insert("a")   // no event
+//		v.Delete("a")   // Deleted [a]
+//	}
+type ObservableSet[T comparable] struct {
+	set Set[T]
+	// mu serializes writes, so events are emitted in order.
+	mu sync.Mutex
+
+	subsMu sync.Mutex
+	subs   map[int]*subscriber[T]
+	nextID int
+}
+
+type subscriber[T comparable] struct {
+	fn func(Event[T])
+
+	// Only used by channel subscribers.
+	mu     sync.Mutex
+	ch     chan Event[T]
+	done   chan struct{}
+	closed bool
+}
+
+// Wraps any set implementing Set.
+func New[T comparable](s Set[T]) *ObservableSet[T] {
+	return &ObservableSet[T]{
+		set:  s,
+		subs: make(map[int]*subscriber[T]),
+	}
+}
+
+// Wraps a `*set.Set[T]`.
+func FromSet[T comparable](s *set.Set[T]) *ObservableSet[T] {
+	return New[T](s)
+}
+
+// Wraps an `*orderedset.OrderedSet[T]`.
+func FromOrderedSet[T comparable](s *orderedset.OrderedSet[T]) *ObservableSet[T] {
+	return New[T](s)
+}
+
+// Wraps a `*concurrentset.ConcurrentSet[T]`.
+func FromConcurrentSet[T comparable](s *concurrentset.ConcurrentSet[T]) *ObservableSet[T] {
+	return New[T](s)
+}
+
+// Registers `fn` to be called synchronously for every event, and returns a function
+// that removes it.
+//
+// `fn` runs in the goroutine that made the change, before the method that made it
+// returns, so it must not modify the ObservableSet.
+func (o *ObservableSet[T]) Subscribe(fn func(Event[T])) (unsubscribe func()) {
+	return o.subscribe(&subscriber[T]{fn: fn})
+}
+
+// Returns a channel receiving every event, and a function that removes the
+// subscription and closes the channel.
+//
+// The channel is buffered with room for `size` events. Once the buffer is full, writes
+// to the ObservableSet block until the receiver catches up or unsubscribes.
+func (o *ObservableSet[T]) Channel(size int) (<-chan Event[T], func()) {
+	sub := &subscriber[T]{
+		ch:   make(chan Event[T], size),
+		done: make(chan struct{}),
+	}
+
+	return sub.ch, o.subscribe(sub)
+}
+
+func (o *ObservableSet[T]) subscribe(sub *subscriber[T]) func() {
+	o.subsMu.Lock()
+	defer o.subsMu.Unlock()
+
+	id := o.nextID
+	o.nextID++
+	o.subs[id] = sub
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			o.subsMu.Lock()
+			delete(o.subs, id)
+			o.subsMu.Unlock()
+
+			if sub.ch != nil {
+				close(sub.done)
+				sub.mu.Lock()
+				sub.closed = true
+				close(sub.ch)
+				sub.mu.Unlock()
+			}
+		})
+	}
+}
+
+func (o *ObservableSet[T]) emit(kind EventKind, values []T) {
+	if len(values) == 0 && kind != Replaced {
+		return
+	}
+
+	o.subsMu.Lock()
+	subs := make([]*subscriber[T], 0, len(o.subs))
+	for _, sub := range o.subs {
+		subs = append(subs, sub)
+	}
+	o.subsMu.Unlock()
+
+	e := Event[T]{Kind: kind, Values: values}
+	for _, sub := range subs {
+		sub.send(e)
+	}
+}
+
+func (sub *subscriber[T]) send(e Event[T]) {
+	if sub.fn != nil {
+		sub.fn(e)
+		return
+	}
+
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+
+	if sub.closed {
+		return
+	}
+
+	select {
+	case sub.ch <- e:
+	case <-sub.done:
+	}
+}
+
+func (o *ObservableSet[T]) Insert(k T) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.set.Contains(k) {
+		return
+	}
+
+	o.set.Insert(k)
+	o.emit(Inserted, []T{k})
+}
+
+// Inserts every value of `seq` and emits a single Inserted event with the new values.
+func (o *ObservableSet[T]) InsertSeq(seq iter.Seq[T]) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	var inserted []T
+	for k := range seq {
+		if !o.set.Contains(k) {
+			o.set.Insert(k)
+			inserted = append(inserted, k)
+		}
+	}
+
+	o.emit(Inserted, inserted)
+}
+
+func (o *ObservableSet[T]) Delete(k T) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if !o.set.Contains(k) {
+		return
+	}
+
+	o.set.Delete(k)
+	o.emit(Deleted, []T{k})
+}
+
+// Removes every value and emits a single Cleared event with the removed values.
+func (o *ObservableSet[T]) Clear() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// OrderedSet.Keys returns its own slice, which Clear overwrites.
+	removed := slices.Clone(o.set.Keys())
+	o.set.Clear()
+	o.emit(Cleared, removed)
+}
+
+// Replaces all values in the set with the values of `seq` and emits a single Replaced event,
+// even if the set holds the same values as before.
+func (o *ObservableSet[T]) Collect(seq iter.Seq[T]) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	// seq may come from the set itself, so it's read before clearing the set.
+	var values []T
+	for k := range seq {
+		values = append(values, k)
+	}
+
+	o.set.Clear()
+	for _, k := range values {
+		o.set.Insert(k)
+	}
+
+	o.emit(Replaced, slices.Clone(o.set.Keys()))
+}
+
+func (o *ObservableSet[T]) Contains(k T) bool {
+	return o.set.Contains(k)
+}
+
+func (o *ObservableSet[T]) Len() int {
+	return o.set.Len()
+}
+
+func (o *ObservableSet[T]) Empty() bool {
+	return o.set.Len() == 0
+}
+
+func (o *ObservableSet[T]) Keys() []T {
+	return o.set.Keys()
+}
+
+func (o *ObservableSet[T]) All() iter.Seq[T] {
+	return o.set.All()
+}
+
+func (o *ObservableSet[T]) String() string {
+	return fmt.Sprint(o.set.Keys())
+}
@@ -0,0 +1,98 @@
+package observableset_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/concurrentset"
+	"github.com/Jamlie/set/observableset"
+	"github.com/Jamlie/set/orderedset"
+)
+
+func TestSubscribe(t *testing.T) {
+	tests := []struct {
+		name string
+		set  *observableset.ObservableSet[int]
+	}{
+		{name: "set", set: observableset.FromSet(set.New[int]())},
+		{name: "orderedset", set: observableset.FromOrderedSet(orderedset.New[int]())},
+		{name: "concurrentset", set: observableset.FromConcurrentSet(concurrentset.New[int]())},
+	}
+
+	for _, test := range tests {
+		var events []observableset.Event[int]
+		unsubscribe := test.set.Subscribe(func(e observableset.Event[int]) {
+			events = append(events, e)
+		})
+
+		test.set.Insert(1)
+		test.set.Insert(1)
+		test.set.InsertSeq(slices.Values([]int{1, 2, 3}))
+		test.set.Delete(2)
+		test.set.Delete(2)
+		test.set.Clear()
+		test.set.Clear()
+		test.set.Collect(slices.Values([]int{7}))
+
+		unsubscribe()
+		test.set.Insert(8)
+
+		expect := []observableset.EventKind{
+			observableset.Inserted,
+			observableset.Inserted,
+			observableset.Deleted,
+			observableset.Cleared,
+			observableset.Replaced,
+		}
+		if len(events) != len(expect) {
+			t.Fatalf("%s: Expected: %d events, Got: %v", test.name, len(expect), events)
+		}
+
+		for i, e := range events {
+			if e.Kind != expect[i] {
+				t.Fatalf("%s: Index: %d, Expected: %v, Got: %v", test.name, i, expect[i], e.Kind)
+			}
+		}
+
+		cleared := events[3].Values
+		slices.Sort(cleared)
+		if len(events[1].Values) != 2 || !slices.Equal(cleared, []int{1, 3}) || !slices.Equal(events[4].Values, []int{7}) {
+			t.Fatalf("%s: Expected: batched values, Got: %v", test.name, events)
+		}
+
+		if test.set.Len() != 2 {
+			t.Fatalf("%s: Expected: %d elements, Got: %s", test.name, 2, test.set)
+		}
+	}
+}
+
+func TestChannel(t *testing.T) {
+	s := observableset.FromSet(set.New[string]())
+
+	events, unsubscribe := s.Channel(1)
+
+	done := make(chan []observableset.Event[string])
+	go func() {
+		var got []observableset.Event[string]
+		for e := range events {
+			got = append(got, e)
+			if len(got) == 3 {
+				unsubscribe()
+			}
+		}
+		done <- got
+	}()
+
+	s.Insert("a")
+	s.Insert("b")
+	s.Delete("a")
+	s.Insert("c") // may or may not be delivered, but must not block
+
+	got := <-done
+	if len(got) < 3 || got[2].Kind != observableset.Deleted {
+		t.Fatalf("Expected: 3 events ending with Deleted, Got: %v", got)
+	}
+
+	s.Insert("d")
+}
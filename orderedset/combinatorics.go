@@ -0,0 +1,136 @@
+package orderedset
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/Jamlie/set/internal"
+)
+
+// Returns a sequence of every subset of `s`, from the smallest to the largest, with
+// subsets of the same size in lexicographic order of the positions of their elements.
+// Each subset is a new set keeping the order of `s`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		for subset := range orderedset.PowerSet(orderedset.FromSlice([]string{"a", "b"})) {
+//			fmt.Println(subset) // [], [a], [b], [a b]
+//		}
+//	}
+func PowerSet[T comparable](s *OrderedSet[T]) iter.Seq[*OrderedSet[T]] {
+	return internal.Collect(PowerSetInto(s, nil), FromSlice)
+}
+
+// Returns a sequence of every subset of `s` as a slice, in the order of PowerSet.
+//
+// Every subset is written into the same slice, starting with `buf` and only growing it
+// when it's too small, so nothing is allocated per subset. The slice is only valid
+// until the next iteration.
+func PowerSetInto[T comparable](s *OrderedSet[T], buf []T) iter.Seq[[]T] {
+	return internal.PowerSetInto(s.keys, buf)
+}
+
+// Returns a sequence of every subset of `s` with `k` elements, in lexicographic order
+// of the positions of their elements. Each subset is a new set keeping the order of `s`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		v := orderedset.FromSlice([]int{1, 2, 3})
+//		for pair := range orderedset.Combinations(v, 2) {
+//			fmt.Println(pair) // [1 2], [1 3], [2 3]
+//		}
+//	}
+func Combinations[T comparable](s *OrderedSet[T], k int) iter.Seq[*OrderedSet[T]] {
+	return internal.Collect(CombinationsInto(s, k, nil), FromSlice)
+}
+
+// Returns a sequence of every subset of `s` with `k` elements as a slice, written into
+// `buf` as with PowerSetInto.
+func CombinationsInto[T comparable](s *OrderedSet[T], k int, buf []T) iter.Seq[[]T] {
+	return internal.CombinationsInto(s.keys, k, buf)
+}
+
+// Returns a sequence of every ordering of the elements of `s`, in lexicographic order
+// of their positions. Each permutation is a new slice.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		v := orderedset.FromSlice([]int{1, 2, 3})
+//		for p := range orderedset.Permutations(v) {
+//			fmt.Println(p) // [1 2 3], [1 3 2], [2 1 3], ...
+//		}
+//	}
+func Permutations[T comparable](s *OrderedSet[T]) iter.Seq[[]T] {
+	return internal.Collect(PermutationsInto(s, nil), slices.Clone)
+}
+
+// Returns a sequence of every ordering of the elements of `s`, written into `buf` as
+// with PowerSetInto.
+func PermutationsInto[T comparable](s *OrderedSet[T], buf []T) iter.Seq[[]T] {
+	return internal.PermutationsInto(s.keys, buf)
+}
+
+// Returns a sequence of every tuple made of one element of each set, in lexicographic
+// order of the positions of the elements. Each tuple is a new slice.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		oses := orderedset.FromSlice([]string{"linux", "darwin"})
+//		archs := orderedset.FromSlice([]string{"amd64", "arm64"})
+//
+//		for tuple := range orderedset.CartesianProduct(oses, archs) {
+//			fmt.Println(tuple) // [linux amd64], [linux arm64], [darwin amd64], [darwin arm64]
+//		}
+//	}
+func CartesianProduct[T comparable](sets ...*OrderedSet[T]) iter.Seq[[]T] {
+	return internal.Collect(CartesianProductInto(nil, sets...), slices.Clone)
+}
+
+// Returns a sequence of every tuple made of one element of each set, written into
+// `buf` as with PowerSetInto.
+func CartesianProductInto[T comparable](buf []T, sets ...*OrderedSet[T]) iter.Seq[[]T] {
+	return internal.ProductInto(sets, (*OrderedSet[T]).keys, buf)
+}
+
+// pick fills `buf` with the keys at the indices `idx`.
+
+// keys returns a copy of the items, so the set can change while they are iterated.
+func (s *OrderedSet[T]) keys() []T {
+	return slices.Clone(s.items)
+}
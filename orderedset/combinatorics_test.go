@@ -0,0 +1,86 @@
+package orderedset_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set/orderedset"
+)
+
+func TestCombinatoricsOrder(t *testing.T) {
+	s := orderedset.FromSlice([]int{3, 1, 2})
+
+	tests := []struct {
+		name   string
+		seq    func(yield func(string) bool)
+		expect []string
+	}{
+		{
+			name: "PowerSet",
+			seq: func(yield func(string) bool) {
+				for v := range orderedset.PowerSet(s) {
+					if !yield(v.String()) {
+						return
+					}
+				}
+			},
+			expect: []string{"[]", "[3]", "[1]", "[2]", "[3 1]", "[3 2]", "[1 2]", "[3 1 2]"},
+		},
+		{
+			name: "Combinations",
+			seq: func(yield func(string) bool) {
+				for v := range orderedset.CombinationsInto(s, 2, nil) {
+					if !yield(fmt.Sprint(v)) {
+						return
+					}
+				}
+			},
+			expect: []string{"[3 1]", "[3 2]", "[1 2]"},
+		},
+		{
+			name: "Permutations",
+			seq: func(yield func(string) bool) {
+				for v := range orderedset.Permutations(s) {
+					if !yield(fmt.Sprint(v)) {
+						return
+					}
+				}
+			},
+			expect: []string{"[3 1 2]", "[3 2 1]", "[1 3 2]", "[1 2 3]", "[2 3 1]", "[2 1 3]"},
+		},
+		{
+			name: "CartesianProduct",
+			seq: func(yield func(string) bool) {
+				for v := range orderedset.CartesianProduct(s, orderedset.FromSlice([]int{0, 9})) {
+					if !yield(fmt.Sprint(v)) {
+						return
+					}
+				}
+			},
+			expect: []string{"[3 0]", "[3 9]", "[1 0]", "[1 9]", "[2 0]", "[2 9]"},
+		},
+	}
+
+	for _, test := range tests {
+		if got := slices.Collect(test.seq); !slices.Equal(got, test.expect) {
+			t.Fatalf("%s, Expected: %v, Got: %v", test.name, test.expect, got)
+		}
+	}
+}
+
+func TestPermutationsStop(t *testing.T) {
+	s := orderedset.FromSlice([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12})
+
+	var first [][]int
+	for p := range orderedset.Permutations(s) {
+		first = append(first, p)
+		if len(first) == 2 {
+			break
+		}
+	}
+
+	if !slices.Equal(first[1], []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 12, 11}) {
+		t.Fatalf("Expected: [1 2 3 4 5 6 7 8 9 10 12 11], Got: %v", first[1])
+	}
+}
@@ -0,0 +1,205 @@
+package orderedset
+
+import (
+	"cmp"
+	"slices"
+	"sort"
+)
+
+// An `Entry` is an element of a Delta together with its index in the set.
+type Entry[T comparable] struct {
+	Value T   `json:"value"`
+	Index int `json:"index"`
+}
+
+// A `Move` is an element that changed position, from its index in the old set to its
+// index in the new one.
+type Move[T comparable] struct {
+	Value T   `json:"value"`
+	From  int `json:"from"`
+	To    int `json:"to"`
+}
+
+// A `Delta` holds the changes, including reorders, that turn one OrderedSet into another.
+//
+// Added holds the new elements with their index in the new set, Removed the deleted
+// elements with their index in the old set, and Moved the elements that have to change
+// position. Diff keeps as many elements in place as possible, so Moved is as short as it
+// can be. A Delta encodes to JSON using its field tags.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		old := orderedset.FromSlice([]string{"a", "b", "c"})
+//		new := orderedset.FromSlice([]string{"c", "a", "d"})
+//
+//		delta := orderedset.Diff(old, new)
+//		fmt.Println(delta.Added)   // [{d 2}]
+//		fmt.Println(delta.Removed) // [{b 1}]
+//		fmt.Println(delta.Moved)   // [{c 2 0}]
+//
+//		old.Apply(delta)
+//		fmt.Println(old) // [c a d]
+//	}
+type Delta[T comparable] struct {
+	Added   []Entry[T] `json:"added"`
+	Removed []Entry[T] `json:"removed"`
+	Moved   []Move[T]  `json:"moved"`
+}
+
+// Returns the changes that turn `old` into `new`, including the order of the elements.
+func Diff[T comparable](old, new *OrderedSet[T]) *Delta[T] {
+	d := &Delta[T]{
+		Added:   []Entry[T]{},
+		Removed: []Entry[T]{},
+		Moved:   []Move[T]{},
+	}
+
+	newIndex := make(map[T]int, new.Len())
+	for i, k := range new.items {
+		newIndex[k] = i
+		if !old.Contains(k) {
+			d.Added = append(d.Added, Entry[T]{Value: k, Index: i})
+		}
+	}
+
+	// The elements found in both sets, in the old order, with their new index.
+	var common []Move[T]
+	for i, k := range old.items {
+		if j, ok := newIndex[k]; ok {
+			common = append(common, Move[T]{Value: k, From: i, To: j})
+		} else {
+			d.Removed = append(d.Removed, Entry[T]{Value: k, Index: i})
+		}
+	}
+
+	// The longest run of elements already in the right relative order stays in place.
+	stay := longestIncreasing(common)
+	for i, m := range common {
+		if !stay[i] {
+			d.Moved = append(d.Moved, m)
+		}
+	}
+
+	return d
+}
+
+// longestIncreasing marks the moves forming a longest subsequence with increasing `To`.
+func longestIncreasing[T comparable](moves []Move[T]) []bool {
+	// tails[l] is the index of the smallest tail of an increasing subsequence of length l+1.
+	tails := make([]int, 0, len(moves))
+	prev := make([]int, len(moves))
+
+	for i, m := range moves {
+		l := sort.Search(len(tails), func(j int) bool {
+			return moves[tails[j]].To >= m.To
+		})
+
+		prev[i] = -1
+		if l > 0 {
+			prev[i] = tails[l-1]
+		}
+
+		if l == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[l] = i
+		}
+	}
+
+	marked := make([]bool, len(moves))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			marked[i] = true
+		}
+	}
+
+	return marked
+}
+
+// Applies the delta: removes the elements it removed and inserts the elements it added
+// or moved at their new index.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		v := orderedset.FromSlice([]int{1, 2, 3})
+//		delta := orderedset.Diff(v, orderedset.FromSlice([]int{3, 1, 4}))
+//
+//		v.Apply(delta)
+//		fmt.Println(v) // [3 1 4]
+//	}
+func (s *OrderedSet[T]) Apply(d *Delta[T]) {
+	drop := make(map[T]struct{}, len(d.Removed)+len(d.Moved))
+	for _, e := range d.Removed {
+		drop[e.Value] = struct{}{}
+	}
+	for _, m := range d.Moved {
+		drop[m.Value] = struct{}{}
+	}
+
+	items := make([]T, 0, len(s.items)+len(d.Added))
+	for _, k := range s.items {
+		if _, ok := drop[k]; ok {
+			delete(s.set, k)
+		} else {
+			items = append(items, k)
+		}
+	}
+
+	inserts := make([]Entry[T], 0, len(d.Added)+len(d.Moved))
+	inserts = append(inserts, d.Added...)
+	for _, m := range d.Moved {
+		inserts = append(inserts, Entry[T]{Value: m.Value, Index: m.To})
+	}
+	slices.SortStableFunc(inserts, func(a, b Entry[T]) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	for _, e := range inserts {
+		if _, exists := s.set[e.Value]; exists {
+			continue
+		}
+
+		s.set[e.Value] = struct{}{}
+		items = slices.Insert(items, min(max(e.Index, 0), len(items)), e.Value)
+	}
+
+	s.items = items
+}
+
+// Undoes the delta, the same as applying its inverse.
+func (s *OrderedSet[T]) Revert(d *Delta[T]) {
+	s.Apply(d.Invert())
+}
+
+// Returns a delta that undoes this one.
+func (d *Delta[T]) Invert() *Delta[T] {
+	inverse := &Delta[T]{
+		Added:   slices.Clone(d.Removed),
+		Removed: slices.Clone(d.Added),
+		Moved:   make([]Move[T], len(d.Moved)),
+	}
+
+	for i, m := range d.Moved {
+		inverse.Moved[i] = Move[T]{Value: m.Value, From: m.To, To: m.From}
+	}
+
+	return inverse
+}
@@ -0,0 +1,64 @@
+package orderedset_test
+
+import (
+	"encoding/json"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set/orderedset"
+)
+
+func TestDiffReportsMoves(t *testing.T) {
+	test := struct {
+		old, new *orderedset.OrderedSet[string]
+		moved    int
+	}{
+		old:   orderedset.FromSlice([]string{"a", "b", "c", "d"}),
+		new:   orderedset.FromSlice([]string{"d", "a", "b", "e"}),
+		moved: 1,
+	}
+
+	delta := orderedset.Diff(test.old, test.new)
+	if len(delta.Moved) != test.moved || delta.Moved[0].Value != "d" {
+		t.Fatalf("Expected: %d move, Got: %v", test.moved, delta.Moved)
+	}
+
+	b, err := json.Marshal(delta)
+	if err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	var decoded orderedset.Delta[string]
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	s := test.old.Clone()
+	s.Apply(&decoded)
+	if !slices.Equal(s.Keys(), test.new.Keys()) {
+		t.Fatalf("Expected: %s, Got: %s", test.new, s)
+	}
+}
+
+func TestDiffApplyRevert(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+
+	for i := range 200 {
+		old := orderedset.FromSlice(r.Perm(10)[:r.IntN(10)])
+		new := orderedset.FromSlice(r.Perm(12)[:r.IntN(12)])
+
+		delta := orderedset.Diff(old, new)
+
+		s := old.Clone()
+		s.Apply(delta)
+		if !slices.Equal(s.Keys(), new.Keys()) {
+			t.Fatalf("Index: %d, Expected: %s, Got: %s", i, new, s)
+		}
+
+		s.Revert(delta)
+		if !slices.Equal(s.Keys(), old.Keys()) {
+			t.Fatalf("Index: %d, Expected: %s, Got: %s", i, old, s)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package orderedset
+
+import (
+	"slices"
+
+	"github.com/Jamlie/set/internal"
+)
+
+// Returns a 64-bit hash of the elements of the set that doesn't depend on their order,
+// the same as `set.Set.Fingerprint` for the same elements.
+//
+// Fingerprints are built on `hash/maphash` with a seed chosen when the program starts,
+// so they can only be compared within a process.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		a := orderedset.FromSlice([]int{1, 2, 3})
+//		b := orderedset.FromSlice([]int{3, 2, 1})
+//		assert.Assert(a.Fingerprint() == b.Fingerprint(), "Should not depend on the order")
+//	}
+func (s *OrderedSet[T]) Fingerprint() uint64 {
+	return internal.Fingerprint(slices.Values(s.items))
+}
+
+// Returns a 64-bit hash of the elements of the set in their order, so sets with the same
+// elements in a different order have different fingerprints.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		a := orderedset.FromSlice([]int{1, 2, 3})
+//		b := orderedset.FromSlice([]int{3, 2, 1})
+//		assert.Assert(a.OrderedFingerprint() != b.OrderedFingerprint(), "Should depend on the order")
+//	}
+func (s *OrderedSet[T]) OrderedFingerprint() uint64 {
+	return internal.OrderedFingerprint(slices.Values(s.items))
+}
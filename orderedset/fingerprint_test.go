@@ -0,0 +1,24 @@
+package orderedset_test
+
+import (
+	"testing"
+
+	"github.com/Jamlie/set/orderedset"
+)
+
+func TestOrderedFingerprint(t *testing.T) {
+	a := orderedset.FromSlice([]string{"a", "b", "c"})
+	b := orderedset.FromSlice([]string{"c", "b", "a"})
+
+	if a.Fingerprint() != b.Fingerprint() {
+		t.Fatalf("Expected: same fingerprint, Got: %x and %x", a.Fingerprint(), b.Fingerprint())
+	}
+
+	if a.OrderedFingerprint() == b.OrderedFingerprint() {
+		t.Fatalf("Expected: different ordered fingerprints, Got: %x", a.OrderedFingerprint())
+	}
+
+	if a.OrderedFingerprint() != a.Clone().OrderedFingerprint() {
+		t.Fatalf("Expected: same ordered fingerprint for a clone")
+	}
+}
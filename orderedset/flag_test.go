@@ -0,0 +1,71 @@
+package orderedset_test
+
+import (
+	"flag"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set/orderedset"
+)
+
+func TestFlagParse(t *testing.T) {
+	test := struct {
+		args   []string
+		expect []string
+	}{
+		args:   []string{"-tag=b, a", "-tag", "c"},
+		expect: []string{"b", "a", "c"},
+	}
+
+	tags := orderedset.NewFlag(func(s string) (string, error) {
+		return s, nil
+	})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(tags, "tag", "")
+
+	if err := fs.Parse(test.args); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	if !slices.Equal(tags.Values().Keys(), test.expect) {
+		t.Fatalf("Expected: %v, Got: %s", test.expect, tags.Values())
+	}
+
+	if tags.String() != "b,a,c" {
+		t.Fatalf("Expected: %s, Got: %s", "b,a,c", tags.String())
+	}
+}
+
+func TestFlagDuplicate(t *testing.T) {
+	tags := orderedset.NewFlag(func(s string) (string, error) {
+		return s, nil
+	})
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Var(tags, "tag", "")
+
+	if err := fs.Parse([]string{"-tag=a", "-tag=b,a"}); err == nil {
+		t.Fatalf("Expected: error, Got: %s", tags.Values())
+	}
+
+	if !slices.Equal(tags.Values().Keys(), []string{"a"}) {
+		t.Fatalf("Expected: %v, Got: %s", []string{"a"}, tags.Values())
+	}
+}
+
+func TestFlagType(t *testing.T) {
+	tests := []struct {
+		flag   interface{ Type() string }
+		expect string
+	}{
+		{flag: orderedset.NewFlag(func(s string) (string, error) { return s, nil }), expect: "stringSet"},
+		{flag: orderedset.NewFlag(func(s string) (any, error) { return s, nil }), expect: "set"},
+	}
+
+	for i, test := range tests {
+		if got := test.flag.Type(); got != test.expect {
+			t.Fatalf("Index: %d, Expected: %s, Got: %s", i, test.expect, got)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package orderedset
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/Jamlie/set/internal"
+)
+
+// A `Frozen` is a read-only OrderedSet.
+//
+// It is created with `OrderedSet.Freeze` and exposes no method that mutates it, so it
+// can be shared between goroutines and read concurrently without any locking. Operations
+// that combine sets return new Frozen sets keeping the order of the receiver first, and
+// `Thaw` returns a mutable copy.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		v := orderedset.New[string]()
+//		v.Insert("admin")
+//		v.Insert("owner")
+//
+//		roles := v.Freeze()
+//		v.Insert("guest") // roles is not affected
+//
+//		go func() {
+//			fmt.Println(roles.Contains("admin"))
+//		}()
+//		fmt.Println(roles) // [admin owner]
+//	}
+type Frozen[T comparable] struct {
+	items []T
+	set   map[T]struct{}
+}
+
+// Returns a read-only copy of the set.
+//
+// Later changes to the set are not visible through the Frozen set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		v := orderedset.New[int]()
+//		v.Insert(1)
+//		frozen := v.Freeze()
+//		v.Insert(2)
+//		assert.Assert(frozen.Len() == 1, "Should not see later changes")
+//	}
+func (s *OrderedSet[T]) Freeze() *Frozen[T] {
+	return wrap(s.Clone())
+}
+
+// Returns a mutable copy of the frozen set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		frozen := orderedset.FromSlice([]int{1, 2}).Freeze()
+//		v := frozen.Thaw()
+//		v.Insert(3)
+//		assert.Assert(frozen.Len() == 2, "Should not change the frozen set")
+//	}
+func (f *Frozen[T]) Thaw() *OrderedSet[T] {
+	return FromSlice(f.items)
+}
+
+// The number of elements the set has.
+func (f *Frozen[T]) Len() int {
+	return len(f.items)
+}
+
+// Returns `true` if the set contains a value.
+func (f *Frozen[T]) Contains(k T) bool {
+	_, exists := f.set[k]
+	return exists
+}
+
+// Returns `true` if the set contains no elements.
+func (f *Frozen[T]) Empty() bool {
+	return len(f.items) == 0
+}
+
+// Returns a copy of the keys of the set in the order the items were inserted in.
+func (f *Frozen[T]) Keys() []T {
+	return slices.Clone(f.items)
+}
+
+// Returns a stringified version of the set with elements in the same order
+func (f Frozen[T]) String() string {
+	return fmt.Sprint(f.items)
+}
+
+// A way to iterate through the Frozen set using a range-loop
+func (f *Frozen[T]) All() iter.Seq[T] {
+	return slices.Values(f.items)
+}
+
+// Returns a new set with the elements of this set followed by the elements of `other`
+// that are not in it.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		a := orderedset.FromSlice([]int{2, 1}).Freeze()
+//		b := orderedset.FromSlice([]int{3, 2}).Freeze()
+//		fmt.Println(a.Union(b)) // [2 1 3]
+//	}
+func (f *Frozen[T]) Union(other *Frozen[T]) *Frozen[T] {
+	return frozen(internal.Union[T](f, other))
+}
+
+// Returns a new set with the elements that are in both sets, in the order of this set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		a := orderedset.FromSlice([]int{3, 2, 1}).Freeze()
+//		b := orderedset.FromSlice([]int{1, 3}).Freeze()
+//		fmt.Println(a.Intersection(b)) // [3 1]
+//	}
+func (f *Frozen[T]) Intersection(other *Frozen[T]) *Frozen[T] {
+	return frozen(internal.Intersection[T](f, other))
+}
+
+// Returns a new set with the elements that are in this set but not in `other`, in the
+// order of this set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		a := orderedset.FromSlice([]int{3, 2, 1}).Freeze()
+//		b := orderedset.FromSlice([]int{2}).Freeze()
+//		fmt.Println(a.Difference(b)) // [3 1]
+//	}
+func (f *Frozen[T]) Difference(other *Frozen[T]) *Frozen[T] {
+	return frozen(internal.Difference[T](f, other))
+}
+
+// Returns a new set with the elements that are in either set, but not in both. The
+// elements of this set come first.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		a := orderedset.FromSlice([]int{1, 2}).Freeze()
+//		b := orderedset.FromSlice([]int{3, 2}).Freeze()
+//		fmt.Println(a.SymmetricDifference(b)) // [1 3]
+//	}
+func (f *Frozen[T]) SymmetricDifference(other *Frozen[T]) *Frozen[T] {
+	return frozen(internal.SymmetricDifference[T](f, other))
+}
+
+// Returns `true` if every element of this set is in `other`, regardless of order.
+func (f *Frozen[T]) IsSubset(other *Frozen[T]) bool {
+	return internal.IsSubset[T](f, other)
+}
+
+// Returns `true` if both sets contain the same elements, regardless of order.
+func (f *Frozen[T]) Equal(other *Frozen[T]) bool {
+	return internal.Equal[T](f, other)
+}
+
+func frozen[T comparable](seq iter.Seq[T]) *Frozen[T] {
+	result := New[T]()
+	for k := range seq {
+		result.Insert(k)
+	}
+
+	return wrap(result)
+}
+
+// wrap freezes a set without copying it, the set must not be used afterwards.
+func wrap[T comparable](s *OrderedSet[T]) *Frozen[T] {
+	return &Frozen[T]{
+		items: s.items,
+		set:   s.set,
+	}
+}
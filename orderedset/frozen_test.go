@@ -0,0 +1,37 @@
+package orderedset_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set/orderedset"
+)
+
+func TestFrozenAlgebra(t *testing.T) {
+	s := orderedset.FromSlice([]int{4, 3, 2, 1})
+	a := s.Freeze()
+	b := orderedset.FromSlice([]int{5, 3, 4}).Freeze()
+	s.Insert(6)
+
+	tests := []struct {
+		name   string
+		result *orderedset.Frozen[int]
+		expect []int
+	}{
+		{name: "freeze", result: a, expect: []int{4, 3, 2, 1}},
+		{name: "union", result: a.Union(b), expect: []int{4, 3, 2, 1, 5}},
+		{name: "intersection", result: a.Intersection(b), expect: []int{4, 3}},
+		{name: "difference", result: a.Difference(b), expect: []int{2, 1}},
+		{name: "symmetric difference", result: a.SymmetricDifference(b), expect: []int{2, 1, 5}},
+	}
+
+	for _, test := range tests {
+		if !slices.Equal(test.result.Keys(), test.expect) {
+			t.Fatalf("%s: Expected: %v, Got: %s", test.name, test.expect, test.result)
+		}
+	}
+
+	if !a.Equal(orderedset.FromSlice([]int{1, 2, 3, 4}).Freeze()) || a.Equal(b) {
+		t.Fatalf("Expected: equality to ignore order")
+	}
+}
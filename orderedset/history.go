@@ -0,0 +1,335 @@
+package orderedset
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+var (
+	// ErrUnknownCheckpoint is returned when restoring a checkpoint that was never created.
+	ErrUnknownCheckpoint = errors.New("orderedset: unknown checkpoint")
+	// ErrCheckpointExpired is returned when restoring a checkpoint that fell out of the
+	// history, either because the history is bounded or because it was on an undone branch
+	// that a later change replaced.
+	ErrCheckpointExpired = errors.New("orderedset: checkpoint is no longer in the history")
+)
+
+// A `History` wraps an OrderedSet and records every change made through it, so that
+// changes can be undone and redone.
+//
+// Each change is stored as the Delta it made rather than a copy of the set, so memory
+// grows with the size of the changes and not with the size of the set. The history keeps
+// at most `limit` changes, dropping the oldest first. Making a change after undoing drops
+// the changes that could have been redone. A History is not safe for concurrent use.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		h := orderedset.NewHistory(orderedset.New[string](), 100)
+//		h.Insert("a")
+//		h.Insert("b")
+//		h.Checkpoint("saved")
+//		h.Delete("a")
+//
+//		h.Undo()
+//		fmt.Println(h) // [a b]
+//		h.Redo()
+//		fmt.Println(h) // [b]
+//
+//		h.Clear()
+//		h.RestoreTo("saved")
+//		fmt.Println(h) // [a b]
+//	}
+type History[T comparable] struct {
+	set   *OrderedSet[T]
+	limit int
+
+	// ops holds the recorded changes, ops[:applied] are applied and the rest can be redone.
+	ops     []*Delta[T]
+	ids     []uint64
+	applied int
+	// floor is the id of the state before ops[0], 0 for the state the history started with.
+	floor  uint64
+	nextID uint64
+
+	checkpoints map[string]uint64
+}
+
+// Create a History recording the changes to `s`, keeping at most `limit` of them.
+//
+// The set should not be modified outside the History afterwards, or undoing will not
+// restore it correctly. This function will panic if limit is not positive.
+//
+// Examples:
+//
+//	package main
+//
+//	import "github.com/Jamlie/set/orderedset"
+//
+//	func main() {
+//		h := orderedset.NewHistory(orderedset.FromSlice([]int{1, 2}), 50)
+//		_ = h
+//	}
+func NewHistory[T comparable](s *OrderedSet[T], limit int) *History[T] {
+	if limit <= 0 {
+		panic("Cannot keep a history with a non-positive limit")
+	}
+
+	return &History[T]{
+		set:         s,
+		limit:       limit,
+		checkpoints: make(map[string]uint64),
+	}
+}
+
+func (h *History[T]) record(d *Delta[T]) {
+	if len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Moved) == 0 {
+		return
+	}
+
+	h.nextID++
+	h.ops = append(h.ops[:h.applied], d)
+	h.ids = append(h.ids[:h.applied], h.nextID)
+	h.applied++
+
+	if len(h.ops) > h.limit {
+		h.floor = h.ids[0]
+		h.ops[0] = nil
+		h.ops = h.ops[1:]
+		h.ids = h.ids[1:]
+		h.applied--
+	}
+}
+
+// Adds a value to the end of the set.
+func (h *History[T]) Insert(k T) {
+	if h.set.Contains(k) {
+		return
+	}
+
+	h.set.Insert(k)
+	h.record(&Delta[T]{Added: []Entry[T]{{Value: k, Index: h.set.Len() - 1}}})
+}
+
+// Adds all values of `seq` to the end of the set, recorded as a single change.
+func (h *History[T]) InsertSeq(seq iter.Seq[T]) {
+	d := &Delta[T]{}
+	for k := range seq {
+		if h.set.Contains(k) {
+			continue
+		}
+
+		h.set.Insert(k)
+		d.Added = append(d.Added, Entry[T]{Value: k, Index: h.set.Len() - 1})
+	}
+
+	h.record(d)
+}
+
+// Removes a value from the set.
+func (h *History[T]) Delete(k T) {
+	i := slices.Index(h.set.items, k)
+	if i < 0 {
+		return
+	}
+
+	h.set.Delete(k)
+	h.record(&Delta[T]{Removed: []Entry[T]{{Value: k, Index: i}}})
+}
+
+// Removes all elements from the set, recorded as a single change.
+func (h *History[T]) Clear() {
+	d := &Delta[T]{Removed: make([]Entry[T], len(h.set.items))}
+	for i, k := range h.set.items {
+		d.Removed[i] = Entry[T]{Value: k, Index: i}
+	}
+
+	h.set.Clear()
+	h.record(d)
+}
+
+// Replaces the elements of the set with the ones of `seq`, recorded as a single change.
+func (h *History[T]) Collect(seq iter.Seq[T]) {
+	next := New[T]()
+	for k := range seq {
+		next.Insert(k)
+	}
+
+	d := Diff(h.set, next)
+	h.set.Apply(d)
+	h.record(d)
+}
+
+// Moves `k` to `index`, shifting the elements in between. An index past the end of the
+// set moves `k` to the end. Moving a value that does not exist will result in nothing.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		h := orderedset.NewHistory(orderedset.FromSlice([]int{1, 2, 3}), 10)
+//		h.Move(3, 0)
+//		fmt.Println(h) // [3 1 2]
+//	}
+func (h *History[T]) Move(k T, index int) {
+	from := slices.Index(h.set.items, k)
+	if from < 0 {
+		return
+	}
+
+	to := min(max(index, 0), h.set.Len()-1)
+	if from == to {
+		return
+	}
+
+	d := &Delta[T]{Moved: []Move[T]{{Value: k, From: from, To: to}}}
+	h.set.Apply(d)
+	h.record(d)
+}
+
+// Undoes the last change, returning `false` if there is nothing to undo.
+func (h *History[T]) Undo() bool {
+	if !h.CanUndo() {
+		return false
+	}
+
+	h.applied--
+	h.set.Revert(h.ops[h.applied])
+	return true
+}
+
+// Reapplies the last undone change, returning `false` if there is nothing to redo.
+func (h *History[T]) Redo() bool {
+	if !h.CanRedo() {
+		return false
+	}
+
+	h.set.Apply(h.ops[h.applied])
+	h.applied++
+	return true
+}
+
+// Returns `true` if there is a change to undo.
+func (h *History[T]) CanUndo() bool {
+	return h.applied > 0
+}
+
+// Returns `true` if there is an undone change to redo.
+func (h *History[T]) CanRedo() bool {
+	return h.applied < len(h.ops)
+}
+
+func (h *History[T]) current() uint64 {
+	if h.applied == 0 {
+		return h.floor
+	}
+
+	return h.ids[h.applied-1]
+}
+
+// Names the current state of the set so it can be returned to with `RestoreTo`.
+//
+// Using a name again moves it to the current state.
+func (h *History[T]) Checkpoint(name string) {
+	h.checkpoints[name] = h.current()
+}
+
+// Undoes or redoes changes until the set is back to the state named `name`.
+//
+// Returns ErrUnknownCheckpoint if there is no such checkpoint, and ErrCheckpointExpired
+// if the changes leading to it are no longer in the history, in which case the set is
+// left unchanged.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"log"
+//
+//		"github.com/Jamlie/set/orderedset"
+//	)
+//
+//	func main() {
+//		h := orderedset.NewHistory(orderedset.New[int](), 10)
+//		h.Checkpoint("empty")
+//		h.Insert(1)
+//		h.Insert(2)
+//
+//		if err := h.RestoreTo("empty"); err != nil {
+//			log.Fatal(err)
+//		}
+//		fmt.Println(h.Len()) // 0
+//	}
+func (h *History[T]) RestoreTo(name string) error {
+	id, ok := h.checkpoints[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownCheckpoint, name)
+	}
+
+	target := 0
+	if id != h.floor {
+		i := slices.Index(h.ids, id)
+		if i < 0 {
+			return fmt.Errorf("%w: %q", ErrCheckpointExpired, name)
+		}
+		target = i + 1
+	}
+
+	for h.applied > target {
+		h.Undo()
+	}
+	for h.applied < target {
+		h.Redo()
+	}
+
+	return nil
+}
+
+// The number of elements the set currently has.
+func (h *History[T]) Len() int {
+	return h.set.Len()
+}
+
+// Returns `true` if the set contains a value.
+func (h *History[T]) Contains(k T) bool {
+	return h.set.Contains(k)
+}
+
+// Returns a copy of the keys of the set in their current order.
+func (h *History[T]) Keys() []T {
+	return slices.Clone(h.set.items)
+}
+
+// Returns a copy of the set in its current state.
+func (h *History[T]) Clone() *OrderedSet[T] {
+	return h.set.Clone()
+}
+
+// Returns a stringified version of the set with elements in the same order
+func (h History[T]) String() string {
+	return fmt.Sprint(h.set.items)
+}
+
+// A way to iterate through the set using a range-loop
+func (h *History[T]) All() iter.Seq[T] {
+	return h.set.All()
+}
@@ -0,0 +1,85 @@
+package orderedset_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set/orderedset"
+)
+
+func TestHistoryUndoRedo(t *testing.T) {
+	h := orderedset.NewHistory(orderedset.FromSlice([]int{1, 2, 3}), 10)
+
+	var states [][]int
+	steps := []func(){
+		func() { h.Insert(4) },
+		func() { h.Delete(2) },
+		func() { h.Move(4, 0) },
+		func() { h.Collect(slices.Values([]int{3, 5, 4})) },
+		func() { h.InsertSeq(slices.Values([]int{6, 3, 7})) },
+		func() { h.Clear() },
+	}
+
+	for _, step := range steps {
+		states = append(states, h.Keys())
+		step()
+	}
+	final := h.Keys()
+
+	for i := len(states) - 1; i >= 0; i-- {
+		if !h.Undo() {
+			t.Fatalf("Index: %d, Expected: undo, Got: nothing to undo", i)
+		}
+		if !slices.Equal(h.Keys(), states[i]) {
+			t.Fatalf("Index: %d, Expected: %v, Got: %s", i, states[i], h)
+		}
+	}
+
+	if h.Undo() {
+		t.Fatalf("Expected: nothing to undo, Got: %s", h)
+	}
+
+	for h.Redo() {
+	}
+	if !slices.Equal(h.Keys(), final) {
+		t.Fatalf("Expected: %v, Got: %s", final, h)
+	}
+}
+
+func TestHistoryCheckpoints(t *testing.T) {
+	h := orderedset.NewHistory(orderedset.New[string](), 3)
+	h.Checkpoint("empty")
+	h.Insert("a")
+	h.Checkpoint("a")
+	h.Insert("b")
+
+	if err := h.RestoreTo("a"); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+	if !slices.Equal(h.Keys(), []string{"a"}) {
+		t.Fatalf("Expected: [a], Got: %s", h)
+	}
+
+	// A new change drops "b" from the history.
+	h.Insert("c")
+	h.Checkpoint("c")
+	if err := h.RestoreTo("empty"); err != nil || h.Len() != 0 {
+		t.Fatalf("Expected: empty set, Got: %s, %v", h, err)
+	}
+	if err := h.RestoreTo("c"); err != nil || !slices.Equal(h.Keys(), []string{"a", "c"}) {
+		t.Fatalf("Expected: [a c], Got: %s, %v", h, err)
+	}
+
+	h.Insert("d")
+	h.Insert("e")
+	if err := h.RestoreTo("empty"); !errors.Is(err, orderedset.ErrCheckpointExpired) {
+		t.Fatalf("Expected: %v, Got: %v", orderedset.ErrCheckpointExpired, err)
+	}
+	if err := h.RestoreTo("missing"); !errors.Is(err, orderedset.ErrUnknownCheckpoint) {
+		t.Fatalf("Expected: %v, Got: %v", orderedset.ErrUnknownCheckpoint, err)
+	}
+	if !slices.Equal(h.Keys(), []string{"a", "c", "d", "e"}) {
+		t.Fatalf("Expected: [a c d e], Got: %s", h)
+	}
+}
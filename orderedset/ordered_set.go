@@ -289,6 +289,7 @@ func (s *OrderedSet[T]) Keys() []T {
 //	}
 func (s *OrderedSet[T]) Clear() {
 	clear(s.items)
+	s.items = s.items[:0]
 	clear(s.set)
 }
 
@@ -53,6 +53,20 @@ func TestSetDelete(t *testing.T) {
 	}
 }
 
+func TestSetClear(t *testing.T) {
+	set := orderedset.FromSlice([]int{1, 2, 3})
+	set.Clear()
+
+	if set.Len() != 0 || len(set.Keys()) != 0 {
+		t.Fatalf("Expected: an empty set, Got: %d elements, %v", set.Len(), set.Keys())
+	}
+
+	set.Insert(4)
+	if !slices.Equal(set.Keys(), []int{4}) {
+		t.Fatalf("Expected: %v, Got: %v", []int{4}, set.Keys())
+	}
+}
+
 func TestSetContains(t *testing.T) {
 	tests := []struct {
 		set      *orderedset.OrderedSet[int]
@@ -0,0 +1,426 @@
+package persistentset
+
+import (
+	"math/bits"
+	"slices"
+)
+
+const (
+	bitsPerLevel = 5
+	levelMask    = 1<<bitsPerLevel - 1
+	// Nodes at maxShift hold every remaining element in a flat list, as the
+	// 60 bits used by the levels above were the same for all of them.
+	maxShift = 60
+)
+
+// A node is never modified once it's reachable from a Set, so it can be shared
+// between any number of versions.
+type node[T comparable] struct {
+	bitmap  uint32
+	entries []entry[T]
+	size    int
+}
+
+// An entry is either a leaf holding a key, or a pointer to a child node.
+type entry[T comparable] struct {
+	hash  uint64
+	key   T
+	child *node[T]
+}
+
+func bitpos(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & levelMask)
+}
+
+func index(bitmap, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
+
+func newNode[T comparable](bitmap uint32, entries []entry[T]) *node[T] {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	size := 0
+	for _, e := range entries {
+		if e.child == nil {
+			size++
+		} else {
+			size += e.child.size
+		}
+	}
+
+	return &node[T]{
+		bitmap:  bitmap,
+		entries: entries,
+		size:    size,
+	}
+}
+
+// compact returns the entry to store for a child node, replacing nodes that
+// only hold one element with the element itself.
+func compact[T comparable](n *node[T]) entry[T] {
+	for n.size == 1 {
+		e := n.entries[0]
+		if e.child == nil {
+			return e
+		}
+		n = e.child
+	}
+
+	return entry[T]{child: n}
+}
+
+// reuse returns `n` instead of an equal node built from scratch, so unchanged
+// subtrees stay shared.
+func reuse[T comparable](n *node[T], bitmap uint32, entries []entry[T]) *node[T] {
+	if n != nil && n.bitmap == bitmap && slices.Equal(n.entries, entries) {
+		return n
+	}
+
+	return newNode(bitmap, entries)
+}
+
+func contains[T comparable](n *node[T], hash uint64, k T, shift uint) bool {
+	for n != nil {
+		if shift >= maxShift {
+			return slices.ContainsFunc(n.entries, func(e entry[T]) bool {
+				return e.key == k
+			})
+		}
+
+		bit := bitpos(hash, shift)
+		if n.bitmap&bit == 0 {
+			return false
+		}
+
+		e := n.entries[index(n.bitmap, bit)]
+		if e.child == nil {
+			return e.key == k
+		}
+
+		n = e.child
+		shift += bitsPerLevel
+	}
+
+	return false
+}
+
+func insert[T comparable](n *node[T], hash uint64, k T, shift uint) (*node[T], bool) {
+	if n == nil {
+		n = &node[T]{}
+	}
+
+	if shift >= maxShift {
+		if contains(n, hash, k, shift) {
+			return n, false
+		}
+
+		entries := append(slices.Clone(n.entries), entry[T]{hash: hash, key: k})
+		return newNode(0, entries), true
+	}
+
+	bit := bitpos(hash, shift)
+	i := index(n.bitmap, bit)
+
+	if n.bitmap&bit == 0 {
+		entries := slices.Insert(slices.Clone(n.entries), i, entry[T]{hash: hash, key: k})
+		return newNode(n.bitmap|bit, entries), true
+	}
+
+	var child *node[T]
+	if e := n.entries[i]; e.child == nil {
+		if e.key == k {
+			return n, false
+		}
+		child, _ = insert(nil, e.hash, e.key, shift+bitsPerLevel)
+		child, _ = insert(child, hash, k, shift+bitsPerLevel)
+	} else {
+		var added bool
+		child, added = insert(e.child, hash, k, shift+bitsPerLevel)
+		if !added {
+			return n, false
+		}
+	}
+
+	entries := slices.Clone(n.entries)
+	entries[i] = entry[T]{child: child}
+	return newNode(n.bitmap, entries), true
+}
+
+func remove[T comparable](n *node[T], hash uint64, k T, shift uint) (*node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	if shift >= maxShift {
+		i := slices.IndexFunc(n.entries, func(e entry[T]) bool {
+			return e.key == k
+		})
+		if i < 0 {
+			return n, false
+		}
+
+		return newNode(0, slices.Delete(slices.Clone(n.entries), i, i+1)), true
+	}
+
+	bit := bitpos(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+
+	i := index(n.bitmap, bit)
+	e := n.entries[i]
+
+	if e.child == nil {
+		if e.key != k {
+			return n, false
+		}
+		return newNode(n.bitmap&^bit, slices.Delete(slices.Clone(n.entries), i, i+1)), true
+	}
+
+	child, removed := remove(e.child, hash, k, shift+bitsPerLevel)
+	if !removed {
+		return n, false
+	}
+
+	if child == nil {
+		return newNode(n.bitmap&^bit, slices.Delete(slices.Clone(n.entries), i, i+1)), true
+	}
+
+	entries := slices.Clone(n.entries)
+	entries[i] = compact(child)
+	return newNode(n.bitmap, entries), true
+}
+
+// each calls yield for every element of the node, stopping when it returns false.
+func each[T comparable](n *node[T], yield func(T) bool) bool {
+	if n == nil {
+		return true
+	}
+
+	for _, e := range n.entries {
+		if e.child == nil {
+			if !yield(e.key) {
+				return false
+			}
+		} else if !each(e.child, yield) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func union[T comparable](a, b *node[T], shift uint) *node[T] {
+	if a == nil || a == b {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+
+	if shift >= maxShift {
+		for _, e := range b.entries {
+			a, _ = insert(a, e.hash, e.key, shift)
+		}
+		return a
+	}
+
+	bitmap := a.bitmap | b.bitmap
+	entries := make([]entry[T], 0, bits.OnesCount32(bitmap))
+
+	for rest := bitmap; rest != 0; rest &= rest - 1 {
+		bit := rest & -rest
+		inA, inB := a.bitmap&bit != 0, b.bitmap&bit != 0
+
+		switch {
+		case !inB:
+			entries = append(entries, a.entries[index(a.bitmap, bit)])
+		case !inA:
+			entries = append(entries, b.entries[index(b.bitmap, bit)])
+		default:
+			ea := a.entries[index(a.bitmap, bit)]
+			eb := b.entries[index(b.bitmap, bit)]
+			entries = append(entries, unionEntry(ea, eb, shift+bitsPerLevel))
+		}
+	}
+
+	return reuse(a, bitmap, entries)
+}
+
+func unionEntry[T comparable](ea, eb entry[T], shift uint) entry[T] {
+	switch {
+	case ea.child == nil && eb.child == nil:
+		if ea.key == eb.key {
+			return ea
+		}
+		child, _ := insert(nil, ea.hash, ea.key, shift)
+		child, _ = insert(child, eb.hash, eb.key, shift)
+		return entry[T]{child: child}
+	case ea.child == nil:
+		child, _ := insert(eb.child, ea.hash, ea.key, shift)
+		return entry[T]{child: child}
+	case eb.child == nil:
+		child, _ := insert(ea.child, eb.hash, eb.key, shift)
+		return entry[T]{child: child}
+	default:
+		return entry[T]{child: union(ea.child, eb.child, shift)}
+	}
+}
+
+func intersection[T comparable](a, b *node[T], shift uint) *node[T] {
+	if a == nil || b == nil {
+		return nil
+	}
+	if a == b {
+		return a
+	}
+
+	if shift >= maxShift {
+		entries := slices.DeleteFunc(slices.Clone(a.entries), func(e entry[T]) bool {
+			return !contains(b, e.hash, e.key, shift)
+		})
+		return reuse(a, 0, entries)
+	}
+
+	var bitmap uint32
+	var entries []entry[T]
+
+	for rest := a.bitmap & b.bitmap; rest != 0; rest &= rest - 1 {
+		bit := rest & -rest
+		ea := a.entries[index(a.bitmap, bit)]
+		eb := b.entries[index(b.bitmap, bit)]
+
+		var kept entry[T]
+		switch {
+		case ea.child == nil:
+			if !containsEntry(eb, ea, shift+bitsPerLevel) {
+				continue
+			}
+			kept = ea
+		case eb.child == nil:
+			if !contains(ea.child, eb.hash, eb.key, shift+bitsPerLevel) {
+				continue
+			}
+			kept = eb
+		default:
+			child := intersection(ea.child, eb.child, shift+bitsPerLevel)
+			if child == nil {
+				continue
+			}
+			kept = compact(child)
+		}
+
+		bitmap |= bit
+		entries = append(entries, kept)
+	}
+
+	return reuse(a, bitmap, entries)
+}
+
+func difference[T comparable](a, b *node[T], shift uint) *node[T] {
+	if a == nil || a == b {
+		return nil
+	}
+	if b == nil {
+		return a
+	}
+
+	if shift >= maxShift {
+		entries := slices.DeleteFunc(slices.Clone(a.entries), func(e entry[T]) bool {
+			return contains(b, e.hash, e.key, shift)
+		})
+		return reuse(a, 0, entries)
+	}
+
+	var bitmap uint32
+	var entries []entry[T]
+
+	for rest := a.bitmap; rest != 0; rest &= rest - 1 {
+		bit := rest & -rest
+		ea := a.entries[index(a.bitmap, bit)]
+
+		kept := ea
+		if b.bitmap&bit != 0 {
+			eb := b.entries[index(b.bitmap, bit)]
+
+			switch {
+			case ea.child == nil:
+				if containsEntry(eb, ea, shift+bitsPerLevel) {
+					continue
+				}
+			case eb.child == nil:
+				child, _ := remove(ea.child, eb.hash, eb.key, shift+bitsPerLevel)
+				if child == nil {
+					continue
+				}
+				kept = compact(child)
+			default:
+				child := difference(ea.child, eb.child, shift+bitsPerLevel)
+				if child == nil {
+					continue
+				}
+				kept = compact(child)
+			}
+		}
+
+		bitmap |= bit
+		entries = append(entries, kept)
+	}
+
+	return reuse(a, bitmap, entries)
+}
+
+func subset[T comparable](a, b *node[T], shift uint) bool {
+	if a == nil || a == b {
+		return true
+	}
+	if b == nil || a.size > b.size {
+		return false
+	}
+
+	if shift >= maxShift {
+		for _, e := range a.entries {
+			if !contains(b, e.hash, e.key, shift) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for rest := a.bitmap; rest != 0; rest &= rest - 1 {
+		bit := rest & -rest
+		if b.bitmap&bit == 0 {
+			return false
+		}
+
+		ea := a.entries[index(a.bitmap, bit)]
+		eb := b.entries[index(b.bitmap, bit)]
+
+		switch {
+		case ea.child == nil:
+			if !containsEntry(eb, ea, shift+bitsPerLevel) {
+				return false
+			}
+		case eb.child == nil:
+			return false
+		default:
+			if !subset(ea.child, eb.child, shift+bitsPerLevel) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// containsEntry reports whether the entry `in` holds the leaf `leaf`, with
+// `shift` being the level of the children of `in`.
+func containsEntry[T comparable](in, leaf entry[T], shift uint) bool {
+	if in.child == nil {
+		return in.key == leaf.key
+	}
+
+	return contains(in.child, leaf.hash, leaf.key, shift)
+}
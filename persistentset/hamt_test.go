@@ -0,0 +1,45 @@
+package persistentset
+
+import "testing"
+
+func TestCollisions(t *testing.T) {
+	var root *node[int]
+	for k := range 10 {
+		root, _ = insert(root, 42, k, 0)
+	}
+
+	other, _ := insert(nil, 42, 3, 0)
+	other, _ = insert(other, 42, 20, 0)
+
+	tests := []struct {
+		name   string
+		result *node[int]
+		expect []int
+	}{
+		{name: "insert", result: root, expect: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}},
+		{name: "union", result: union(root, other, 0), expect: []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 20}},
+		{name: "intersection", result: intersection(root, other, 0), expect: []int{3}},
+		{name: "difference", result: difference(other, root, 0), expect: []int{20}},
+	}
+
+	for _, test := range tests {
+		s := &PersistentSet[int]{root: test.result}
+		if s.Len() != len(test.expect) {
+			t.Fatalf("%s: Expected: %v, Got: %s", test.name, test.expect, s)
+		}
+
+		for _, k := range test.expect {
+			if !contains(test.result, 42, k, 0) {
+				t.Fatalf("%s: Expected: %v, Got: %s", test.name, test.expect, s)
+			}
+		}
+	}
+
+	for k := range 10 {
+		root, _ = remove(root, 42, k, 0)
+	}
+
+	if root != nil {
+		t.Fatalf("Expected: an empty trie, Got: %d elements", root.size)
+	}
+}
@@ -0,0 +1,382 @@
+// Package persistentset provides a generic implementation of a persistent set.
+//
+// A PersistentSet is an immutable collection of unique elements, implemented as a
+// hash array mapped trie. Adding or removing an element returns a new version of the
+// set in O(log n) while sharing most of its structure with the previous one, which
+// makes keeping many versions that differ by a few elements cheap.
+// The Set is parameterized with a type T, which must be comparable.
+package persistentset
+
+import (
+	"fmt"
+	"hash/maphash"
+	"iter"
+)
+
+var seed = maphash.MakeSeed()
+
+// A `PersistentSet` is implemented as a hash array mapped trie.
+//
+// A PersistentSet is never modified, `With` and `Without` return new versions instead,
+// so every version can be kept and read from many goroutines without any locking.
+// The zero value is an empty set.
+//
+// As with maps, a PersistentSet requires T to be a comparable, meaning it can
+// accept structs if and only if they don't have a type
+// like a slice/map/anything that is not comparable
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		v1 := persistentset.New[int]().With(1).With(2)
+//		v2 := v1.With(3)
+//		v3 := v2.Without(1)
+//
+//		fmt.Println(v1.Len(), v2.Len(), v3.Len()) // 2 3 2
+//		fmt.Println(v1.Contains(1), v3.Contains(1)) // true false
+//	}
+type PersistentSet[T comparable] struct {
+	root *node[T]
+}
+
+// Create a new empty PersistentSet.
+//
+// Examples:
+//
+//	package main
+//
+//	import "github.com/Jamlie/set/persistentset"
+//
+//	func main() {
+//		v := persistentset.New[int]()
+//		_ = v
+//	}
+func New[T comparable]() *PersistentSet[T] {
+	return &PersistentSet[T]{}
+}
+
+func hash[T comparable](k T) uint64 {
+	return maphash.Comparable(seed, k)
+}
+
+// Returns a new version of the set that contains `k`.
+//
+// The set itself is left unchanged. If `k` is already in the set the same set is returned.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		v1 := persistentset.New[int]()
+//		v2 := v1.With(1)
+//		assert.Assert(v1.Len() == 0, "Should not change the previous version")
+//		assert.Assert(v2.Len() == 1, "Should contain the new value")
+//	}
+func (s *PersistentSet[T]) With(k T) *PersistentSet[T] {
+	root, added := insert(s.root, hash(k), k, 0)
+	if !added {
+		return s
+	}
+
+	return &PersistentSet[T]{root: root}
+}
+
+// Returns a new version of the set that does not contain `k`.
+//
+// The set itself is left unchanged. If `k` is not in the set the same set is returned.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		v1 := persistentset.New[int]().With(1).With(2)
+//		v2 := v1.Without(1)
+//		assert.Assert(v1.Contains(1), "Should not change the previous version")
+//		assert.Assert(!v2.Contains(1), "Should not contain the removed value")
+//	}
+func (s *PersistentSet[T]) Without(k T) *PersistentSet[T] {
+	root, removed := remove(s.root, hash(k), k, 0)
+	if !removed {
+		return s
+	}
+
+	return &PersistentSet[T]{root: root}
+}
+
+// The number of elements the set has.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		v := persistentset.FromSlice([]int{1, 2, 3})
+//		assert.Assert(v.Len() == 3, "Gets the number of elements")
+//	}
+func (s *PersistentSet[T]) Len() int {
+	if s.root == nil {
+		return 0
+	}
+
+	return s.root.size
+}
+
+// Returns `true` if the set contains a value.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"github.com/Jamlie/assert"
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		v := persistentset.FromSlice([]int{1, 2, 4})
+//		assert.Assert(v.Contains(3) == false, "Number doesn't exist")
+//		assert.Assert(v.Contains(4) == true, "Number exist")
+//	}
+func (s *PersistentSet[T]) Contains(k T) bool {
+	return contains(s.root, hash(k), k, 0)
+}
+
+// Returns `true` if the set contains no elements.
+func (s *PersistentSet[T]) Empty() bool {
+	return s.root == nil
+}
+
+// Returns a slice containing the keys of the set in an arbitrary order.
+func (s *PersistentSet[T]) Keys() []T {
+	keys := make([]T, 0, s.Len())
+
+	for k := range s.All() {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// Returns a stringified version of the set with elements in an arbitrary order
+func (s PersistentSet[T]) String() string {
+	return fmt.Sprint(s.Keys())
+}
+
+// A way to iterate through PersistentSet using a range-loop
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"log"
+//
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		v := persistentset.FromSlice([]int{3, 2, 1})
+//
+//		for k := range v.All() {
+//			log.Println(k)
+//		}
+//	}
+func (s *PersistentSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		each(s.root, yield)
+	}
+}
+
+// Returns a new set with the elements that are in either set.
+//
+// Subtrees shared by both sets are reused as they are.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		a := persistentset.FromSlice([]int{1, 2})
+//		b := persistentset.FromSlice([]int{2, 3})
+//		fmt.Println(a.Union(b)) // [1 2 3]
+//	}
+func (s *PersistentSet[T]) Union(other *PersistentSet[T]) *PersistentSet[T] {
+	return s.version(union(s.root, other.root, 0), other)
+}
+
+// Returns a new set with the elements that are in both sets.
+//
+// Subtrees shared by both sets are reused as they are.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		a := persistentset.FromSlice([]int{1, 2})
+//		b := persistentset.FromSlice([]int{2, 3})
+//		fmt.Println(a.Intersection(b)) // [2]
+//	}
+func (s *PersistentSet[T]) Intersection(other *PersistentSet[T]) *PersistentSet[T] {
+	return s.version(intersection(s.root, other.root, 0), other)
+}
+
+// Returns a new set with the elements that are in this set but not in `other`.
+//
+// Subtrees shared by both sets are dropped without being visited.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		a := persistentset.FromSlice([]int{1, 2})
+//		b := persistentset.FromSlice([]int{2, 3})
+//		fmt.Println(a.Difference(b)) // [1]
+//	}
+func (s *PersistentSet[T]) Difference(other *PersistentSet[T]) *PersistentSet[T] {
+	return s.version(difference(s.root, other.root, 0), other)
+}
+
+// Returns a new set with the elements that are in either set, but not in both.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		a := persistentset.FromSlice([]int{1, 2})
+//		b := persistentset.FromSlice([]int{2, 3})
+//		fmt.Println(a.SymmetricDifference(b)) // [1 3]
+//	}
+func (s *PersistentSet[T]) SymmetricDifference(other *PersistentSet[T]) *PersistentSet[T] {
+	root := union(difference(s.root, other.root, 0), difference(other.root, s.root, 0), 0)
+	return s.version(root, other)
+}
+
+// version returns whichever of the two sets already has `root`, or a new set.
+func (s *PersistentSet[T]) version(root *node[T], other *PersistentSet[T]) *PersistentSet[T] {
+	switch root {
+	case s.root:
+		return s
+	case other.root:
+		return other
+	default:
+		return &PersistentSet[T]{root: root}
+	}
+}
+
+// Returns `true` if every element of this set is in `other`.
+func (s *PersistentSet[T]) IsSubset(other *PersistentSet[T]) bool {
+	return subset(s.root, other.root, 0)
+}
+
+// Returns `true` if both sets contain the same elements.
+func (s *PersistentSet[T]) Equal(other *PersistentSet[T]) bool {
+	return s.Len() == other.Len() && s.IsSubset(other)
+}
+
+// Converts a slice into a set
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		arr := []string{"first", "second", "last"}
+//
+//		v := persistentset.FromSlice(arr)
+//
+//		fmt.Println(v)
+//	}
+func FromSlice[Slice ~[]T, T comparable](v Slice) *PersistentSet[T] {
+	var root *node[T]
+
+	for _, k := range v {
+		root, _ = insert(root, hash(k), k, 0)
+	}
+
+	return &PersistentSet[T]{root: root}
+}
+
+// Converts any `iter.Seq[T]` into a set, such as `set.Set.All`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//		"github.com/Jamlie/set/persistentset"
+//	)
+//
+//	func main() {
+//		v := persistentset.FromSeq(set.FromSlice([]int{1, 2, 3}).All())
+//		fmt.Println(v)
+//	}
+func FromSeq[T comparable](seq iter.Seq[T]) *PersistentSet[T] {
+	var root *node[T]
+
+	for k := range seq {
+		root, _ = insert(root, hash(k), k, 0)
+	}
+
+	return &PersistentSet[T]{root: root}
+}
@@ -0,0 +1,133 @@
+package persistentset_test
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/persistentset"
+)
+
+func TestWithWithout(t *testing.T) {
+	test := struct {
+		set    *persistentset.PersistentSet[int]
+		expect *set.Set[int]
+	}{
+		set:    persistentset.New[int](),
+		expect: set.New[int](),
+	}
+
+	versions := []*persistentset.PersistentSet[int]{test.set}
+	lens := []int{0}
+
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 5000 {
+		k := r.IntN(1000)
+		if r.IntN(3) == 0 {
+			test.set = test.set.Without(k)
+			test.expect.Delete(k)
+		} else {
+			test.set = test.set.With(k)
+			test.expect.Insert(k)
+		}
+
+		versions = append(versions, test.set)
+		lens = append(lens, test.expect.Len())
+	}
+
+	if test.set.Len() != test.expect.Len() {
+		t.Fatalf("Expected: %d, Got: %d", test.expect.Len(), test.set.Len())
+	}
+
+	for k := range 1000 {
+		if test.set.Contains(k) != test.expect.Contains(k) {
+			t.Fatalf("Contains(%d) Expected: %v, Got: %v", k, test.expect.Contains(k), !test.expect.Contains(k))
+		}
+	}
+
+	for i, v := range versions {
+		if v.Len() != lens[i] || len(v.Keys()) != lens[i] {
+			t.Fatalf("Version: %d, Expected: %d, Got: %d", i, lens[i], v.Len())
+		}
+	}
+}
+
+func TestUnchangedVersionIsShared(t *testing.T) {
+	v := persistentset.FromSlice([]int{1, 2, 3})
+
+	if v.With(1) != v || v.Without(4) != v {
+		t.Fatalf("Expected: the same version when nothing changes")
+	}
+}
+
+func TestAlgebra(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+
+	base := persistentset.New[int]()
+	for range 2000 {
+		base = base.With(r.IntN(5000))
+	}
+
+	a, b := base, base
+	for range 200 {
+		a = a.With(r.IntN(5000)).Without(r.IntN(5000))
+		b = b.With(r.IntN(5000)).Without(r.IntN(5000))
+	}
+
+	ma, mb := set.FromSlice(a.Keys()), set.FromSlice(b.Keys())
+
+	tests := []struct {
+		name   string
+		result *persistentset.PersistentSet[int]
+		expect func(k int) bool
+	}{
+		{
+			name:   "union",
+			result: a.Union(b),
+			expect: func(k int) bool { return ma.Contains(k) || mb.Contains(k) },
+		},
+		{
+			name:   "intersection",
+			result: a.Intersection(b),
+			expect: func(k int) bool { return ma.Contains(k) && mb.Contains(k) },
+		},
+		{
+			name:   "difference",
+			result: a.Difference(b),
+			expect: func(k int) bool { return ma.Contains(k) && !mb.Contains(k) },
+		},
+		{
+			name:   "symmetric difference",
+			result: a.SymmetricDifference(b),
+			expect: func(k int) bool { return ma.Contains(k) != mb.Contains(k) },
+		},
+	}
+
+	for _, test := range tests {
+		n := 0
+		for k := range 5000 {
+			if test.result.Contains(k) != test.expect(k) {
+				t.Fatalf("%s: Contains(%d) Expected: %v, Got: %v", test.name, k, test.expect(k), !test.expect(k))
+			}
+			if test.expect(k) {
+				n++
+			}
+		}
+
+		if test.result.Len() != n || len(test.result.Keys()) != n {
+			t.Fatalf("%s: Expected: %d, Got: %d", test.name, n, test.result.Len())
+		}
+	}
+
+	if !a.Intersection(b).IsSubset(a) || !a.IsSubset(a.Union(b)) || a.Union(b).IsSubset(a.Intersection(b)) {
+		t.Fatalf("Expected: subsets to be detected")
+	}
+
+	if !a.Equal(persistentset.FromSeq(ma.All())) || a.Equal(b) {
+		t.Fatalf("Expected: equal sets to compare equal")
+	}
+
+	if a.Union(a) != a || a.Intersection(a) != a || !a.Difference(a).Empty() {
+		t.Fatalf("Expected: operations on the same set to reuse it")
+	}
+}
@@ -0,0 +1,210 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/Jamlie/set/internal"
+)
+
+// Appends the set to `b` as a protobuf packed repeated field with the field number `num`
+// and returns the extended buffer.
+//
+// Each element is written as a varint, the same way protobuf encodes `int32`, `int64`,
+// `uint32`, `uint64`, `bool` and enum fields. An empty set writes nothing, matching proto3.
+// This function will panic if `num` is not a valid field number.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.New[int64]()
+//		v.Insert(150)
+//
+//		b := set.AppendPackedVarint(nil, 1, v)
+//		fmt.Printf("% x\n", b) // 0a 02 96 01
+//	}
+func AppendPackedVarint[T internal.Integer](b []byte, num int, s *Set[T]) []byte {
+	if s.Empty() {
+		return b
+	}
+
+	size := 0
+	for k := range s.set {
+		size += internal.SizeVarint(uint64(k))
+	}
+
+	b = internal.AppendTag(b, num, internal.WireBytes)
+	b = internal.AppendVarint(b, uint64(size))
+	for k := range s.set {
+		b = internal.AppendVarint(b, uint64(k))
+	}
+
+	return b
+}
+
+// Appends the set to `b` as a protobuf repeated `string` or `bytes` field with the field
+// number `num` and returns the extended buffer.
+//
+// Every element is written as its own length-delimited record, as protobuf never packs
+// strings. This function will panic if `num` is not a valid field number.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.New[string]()
+//		v.Insert("testing")
+//
+//		b := set.AppendStrings(nil, 2, v)
+//		fmt.Printf("% x\n", b) // 12 07 74 65 73 74 69 6e 67
+//	}
+func AppendStrings[T ~string](b []byte, num int, s *Set[T]) []byte {
+	for k := range s.set {
+		b = internal.AppendTag(b, num, internal.WireBytes)
+		b = internal.AppendVarint(b, uint64(len(k)))
+		b = append(b, k...)
+	}
+
+	return b
+}
+
+// Reads every occurrence of the field `num` from the protobuf message `b` and inserts
+// the decoded integers into the set.
+//
+// Both packed and unpacked encodings are accepted, and other fields are skipped.
+// Values are converted to T the same way protobuf narrows them, by truncation.
+// Elements decoded before an error is found are kept in the set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"log"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.New[int64]()
+//		if err := set.DecodePackedVarint([]byte{0x0a, 0x02, 0x96, 0x01}, 1, v); err != nil {
+//			log.Fatal(err)
+//		}
+//		log.Println(v) // [150]
+//	}
+func DecodePackedVarint[T internal.Integer](b []byte, num int, s *Set[T]) error {
+	var err error
+	s.InsertSeq(varints[T](b, num, &err))
+	return err
+}
+
+// Reads every occurrence of the `string` or `bytes` field `num` from the protobuf
+// message `b` and inserts the decoded values into the set.
+//
+// Other fields are skipped. Elements decoded before an error is found are kept in the set.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"log"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.New[string]()
+//		b := []byte{0x12, 0x07, 0x74, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67}
+//		if err := set.DecodeStrings(b, 2, v); err != nil {
+//			log.Fatal(err)
+//		}
+//		log.Println(v) // [testing]
+//	}
+func DecodeStrings[T ~string](b []byte, num int, s *Set[T]) error {
+	var err error
+	s.InsertSeq(stringsField[T](b, num, &err))
+	return err
+}
+
+func varints[T internal.Integer](b []byte, num int, err *error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(b) > 0 {
+			n, typ, value, m, e := internal.ConsumeField(b)
+			if e != nil {
+				*err = e
+				return
+			}
+			b = b[m:]
+
+			if n != num {
+				continue
+			}
+
+			switch typ {
+			case internal.WireVarint:
+				v, _, _ := internal.ConsumeVarint(value)
+				if !yield(T(v)) {
+					return
+				}
+			case internal.WireBytes:
+				for len(value) > 0 {
+					v, l, e := internal.ConsumeVarint(value)
+					if e != nil {
+						*err = e
+						return
+					}
+					value = value[l:]
+
+					if !yield(T(v)) {
+						return
+					}
+				}
+			default:
+				*err = fmt.Errorf("protowire: field %d has wire type %d, expected a varint", n, typ)
+				return
+			}
+		}
+	}
+}
+
+func stringsField[T ~string](b []byte, num int, err *error) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(b) > 0 {
+			n, typ, value, m, e := internal.ConsumeField(b)
+			if e != nil {
+				*err = e
+				return
+			}
+			b = b[m:]
+
+			if n != num {
+				continue
+			}
+
+			if typ != internal.WireBytes {
+				*err = fmt.Errorf("protowire: field %d has wire type %d, expected length-delimited", n, typ)
+				return
+			}
+
+			if !yield(T(value)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package set_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Jamlie/set"
+)
+
+func TestAppendPackedVarint(t *testing.T) {
+	test := struct {
+		set    *set.Set[int32]
+		expect []byte
+	}{
+		set:    set.FromSlice([]int32{150}),
+		expect: []byte{0x0a, 0x02, 0x96, 0x01},
+	}
+
+	if b := set.AppendPackedVarint(nil, 1, test.set); !bytes.Equal(b, test.expect) {
+		t.Fatalf("Expected: % x, Got: % x", test.expect, b)
+	}
+}
+
+func TestDecodePackedVarint(t *testing.T) {
+	tests := []struct {
+		input  []byte
+		expect []int64
+	}{
+		{
+			// packed, as in the protobuf encoding guide
+			input:  []byte{0x0a, 0x06, 0x03, 0x8e, 0x02, 0x9e, 0xa7, 0x05},
+			expect: []int64{3, 270, 86942},
+		},
+		{
+			// unpacked, with an unrelated string field in between
+			input:  []byte{0x08, 0x03, 0x12, 0x01, 0x61, 0x08, 0x8e, 0x02},
+			expect: []int64{3, 270},
+		},
+		{
+			input:  set.AppendPackedVarint(nil, 1, set.FromSlice([]int64{-1, 0, 1 << 40})),
+			expect: []int64{-1, 0, 1 << 40},
+		},
+	}
+
+	for i, test := range tests {
+		s := set.New[int64]()
+		if err := set.DecodePackedVarint(test.input, 1, s); err != nil {
+			t.Fatalf("Index: %d, Expected: no error, Got: %v", i, err)
+		}
+
+		if !sameSlice(s.Keys(), test.expect) {
+			t.Fatalf("Index: %d, Expected: %v, Got: %s", i, test.expect, s)
+		}
+	}
+}
+
+func TestDecodeStrings(t *testing.T) {
+	test := struct {
+		set *set.Set[string]
+	}{
+		set: set.FromSlice([]string{"first", "", "last"}),
+	}
+
+	b := set.AppendPackedVarint(nil, 1, set.FromSlice([]int{7}))
+	b = set.AppendStrings(b, 2, test.set)
+
+	s := set.New[string]()
+	if err := set.DecodeStrings(b, 2, s); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	if !sameSlice(s.Keys(), test.set.Keys()) {
+		t.Fatalf("Expected: %s, Got: %s", test.set, s)
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	s := set.New[int]()
+	if err := set.DecodePackedVarint([]byte{0x0a, 0x03, 0x01}, 1, s); err == nil {
+		t.Fatalf("Expected: error, Got: %s", s)
+	}
+}
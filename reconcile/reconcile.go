@@ -0,0 +1,185 @@
+// Package reconcile provides a way for two replicas of a set to find and exchange only
+// the elements that differ between them.
+//
+// Each side sends a Sketch, an invertible Bloom lookup table, whose size depends on the
+// expected number of differences and not on the size of the sets. Subtracting the
+// sketches reveals the hashes of the elements only one side has, and each side then
+// sends the elements the other one is missing.
+package reconcile
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"hash/fnv"
+	"io"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/internal"
+)
+
+// Returns a 64-bit hash of `k` that is the same in every process, as needed to compare
+// the sketches of two replicas.
+//
+// Strings and integers are hashed directly, other values are hashed through their
+// `%#v` formatting, so values holding pointers don't hash the same across processes.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/reconcile"
+//	)
+//
+//	func main() {
+//		fmt.Println(reconcile.Hash("a") == reconcile.Hash("a")) // true
+//	}
+func Hash[T comparable](k T) uint64 {
+	switch v := any(k).(type) {
+	case string:
+		h := fnv.New64a()
+		h.Write([]byte(v))
+		return h.Sum64()
+	case int:
+		return mix(uint64(v))
+	case int8:
+		return mix(uint64(v))
+	case int16:
+		return mix(uint64(v))
+	case int32:
+		return mix(uint64(v))
+	case int64:
+		return mix(uint64(v))
+	case uint:
+		return mix(uint64(v))
+	case uint8:
+		return mix(uint64(v))
+	case uint16:
+		return mix(uint64(v))
+	case uint32:
+		return mix(uint64(v))
+	case uint64:
+		return mix(v)
+	case uintptr:
+		return mix(uint64(v))
+	default:
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%#v", k)
+		return h.Sum64()
+	}
+}
+
+// maxDiff is the largest difference Reconcile sizes a sketch for, so that the encoded
+// sketch always fits in a frame.
+const maxDiff = (internal.MaxFrame/(binary.MaxVarintLen64+16) - 12 - hashes) * 2 / 3
+
+// Returns a sketch of the hashes of the elements of `s`, sized for `diff` differences.
+func SketchOf[T comparable](s *set.Set[T], diff int) *Sketch {
+	sketch := NewSketch(diff)
+	for k := range s.All() {
+		sketch.Add(Hash(k))
+	}
+
+	return sketch
+}
+
+// Reconciles `s` with the replica on the other end of `rw`, which must call Reconcile
+// at the same time, and inserts the elements only the other replica has into `s`.
+//
+// `diff` is the expected number of elements that are in one of the sets but not in
+// both; the larger of the values given by both sides is used, up to about 6.8 million.
+// A peer asking for more is rejected with ErrMalformed. If the sets differ by
+// too much, both sides return ErrUndecodable without changing their set, and can try
+// again with a larger `diff`. Elements are sent with encoding/gob.
+//
+// Returns the elements received from the other replica.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"net"
+//
+//		"github.com/Jamlie/set"
+//		"github.com/Jamlie/set/reconcile"
+//	)
+//
+//	func main() {
+//		a := set.FromSlice([]int{1, 2, 3})
+//		b := set.FromSlice([]int{2, 3, 4})
+//
+//		left, right := net.Pipe()
+//		go reconcile.Reconcile(right, b, 10)
+//
+//		received, err := reconcile.Reconcile(left, a, 10)
+//		fmt.Println(received, err) // [4] <nil>
+//		fmt.Println(a.Len()) // 4
+//	}
+func Reconcile[T comparable](rw io.ReadWriter, s *set.Set[T], diff int) (*set.Set[T], error) {
+	diff = min(max(diff, 0), maxDiff)
+	in, err := internal.Exchange(rw, binary.AppendUvarint(nil, uint64(diff)))
+	if err != nil {
+		return nil, err
+	}
+
+	theirDiff, n := binary.Uvarint(in)
+	if n <= 0 || theirDiff > maxDiff {
+		return nil, ErrMalformed
+	}
+
+	ours := SketchOf(s, max(diff, int(theirDiff)))
+	b, err := ours.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	if b, err = internal.Exchange(rw, b); err != nil {
+		return nil, err
+	}
+
+	var theirs Sketch
+	if err := theirs.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	if theirs.Len() != ours.Len() {
+		return nil, ErrMalformed
+	}
+
+	ours.Subtract(&theirs)
+	missing, _, err := ours.Decode()
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := set.FromSlice(missing)
+	send := make([]T, 0, len(missing))
+	for k := range s.All() {
+		if wanted.Contains(Hash(k)) {
+			send = append(send, k)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(send); err != nil {
+		return nil, err
+	}
+
+	if b, err = internal.Exchange(rw, buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	var received []T
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&received); err != nil {
+		return nil, err
+	}
+
+	result := set.FromSlice(received)
+	s.InsertSeq(result.All())
+	return result, nil
+}
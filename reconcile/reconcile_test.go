@@ -0,0 +1,99 @@
+package reconcile_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/reconcile"
+)
+
+type result[T comparable] struct {
+	received *set.Set[T]
+	err      error
+}
+
+func reconcilePipe[T comparable](a, b *set.Set[T], diffA, diffB int) (result[T], result[T]) {
+	left, right := net.Pipe()
+	defer left.Close()
+	defer right.Close()
+
+	done := make(chan result[T])
+	go func() {
+		received, err := reconcile.Reconcile(right, b, diffB)
+		done <- result[T]{received, err}
+	}()
+
+	received, err := reconcile.Reconcile(left, a, diffA)
+	return result[T]{received, err}, <-done
+}
+
+func TestReconcile(t *testing.T) {
+	a := set.New[string]()
+	b := set.New[string]()
+	for i := range 10_000 {
+		a.Insert(fmt.Sprint(i))
+		b.Insert(fmt.Sprint(i + 20))
+	}
+
+	ra, rb := reconcilePipe(a, b, 10, 50)
+	if ra.err != nil || rb.err != nil {
+		t.Fatalf("Expected: no error, Got: %v, %v", ra.err, rb.err)
+	}
+
+	if ra.received.Len() != 20 || !ra.received.Contains("10019") {
+		t.Fatalf("Expected: 20 elements from 10000 to 10019, Got: %s", ra.received)
+	}
+	if rb.received.Len() != 20 || !rb.received.Contains("0") {
+		t.Fatalf("Expected: 20 elements from 0 to 19, Got: %s", rb.received)
+	}
+
+	if a.Len() != 10_020 || b.Len() != 10_020 {
+		t.Fatalf("Expected: both sets with 10020 elements, Got: %d and %d", a.Len(), b.Len())
+	}
+}
+
+func TestReconcileUndecodable(t *testing.T) {
+	a := set.New[int]()
+	b := set.New[int]()
+	for i := range 1_000 {
+		a.Insert(i)
+		b.Insert(-i - 1)
+	}
+
+	ra, rb := reconcilePipe(a, b, 5, 5)
+	if !errors.Is(ra.err, reconcile.ErrUndecodable) || !errors.Is(rb.err, reconcile.ErrUndecodable) {
+		t.Fatalf("Expected: %v, Got: %v, %v", reconcile.ErrUndecodable, ra.err, rb.err)
+	}
+
+	if a.Len() != 1_000 || b.Len() != 1_000 {
+		t.Fatalf("Expected: unchanged sets, Got: %d and %d", a.Len(), b.Len())
+	}
+
+	ra, rb = reconcilePipe(a, b, 2_500, 0)
+	if ra.err != nil || rb.err != nil || a.Len() != 2_000 || b.Len() != 2_000 {
+		t.Fatalf("Expected: reconciled sets, Got: %d and %d, %v, %v", a.Len(), b.Len(), ra.err, rb.err)
+	}
+}
+
+func TestReconcileDiffTooLarge(t *testing.T) {
+	left, right := net.Pipe()
+	defer left.Close()
+	defer right.Close()
+
+	// a peer asking for a sketch too large to be sent in a frame
+	go func() {
+		diff := binary.AppendUvarint(nil, 1<<24)
+		right.Write(append(binary.BigEndian.AppendUint32(nil, uint32(len(diff))), diff...))
+	}()
+	go io.Copy(io.Discard, right)
+
+	_, err := reconcile.Reconcile(left, set.FromSlice([]int{1, 2, 3}), 10)
+	if !errors.Is(err, reconcile.ErrMalformed) {
+		t.Fatalf("Expected: %v, Got: %v", reconcile.ErrMalformed, err)
+	}
+}
@@ -0,0 +1,227 @@
+package reconcile
+
+import (
+	"encoding/binary"
+	"errors"
+	"slices"
+)
+
+// ErrUndecodable is returned when a sketch holds more differences than it can recover.
+var ErrUndecodable = errors.New("reconcile: the difference is too large for the sketch")
+
+// ErrMalformed is returned when decoding a sketch that was not encoded by MarshalBinary.
+var ErrMalformed = errors.New("reconcile: malformed sketch")
+
+// hashes is the number of cells each key is added to.
+const hashes = 3
+
+type cell struct {
+	count   int64
+	keySum  uint64
+	hashSum uint64
+}
+
+// A `Sketch` is an invertible Bloom lookup table of 64-bit keys.
+//
+// Subtracting the sketch of one set from the sketch of another leaves only the keys that
+// are in one of the sets but not in both, which `Decode` recovers as long as there are
+// not many more of them than the sketch was sized for. The size of a sketch depends on
+// that difference and not on the size of the sets.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set/reconcile"
+//	)
+//
+//	func main() {
+//		a := reconcile.NewSketch(10)
+//		b := reconcile.NewSketch(10)
+//		for k := range uint64(1000) {
+//			a.Add(k)
+//			b.Add(k + 2)
+//		}
+//
+//		a.Subtract(b)
+//		onlyA, onlyB, _ := a.Decode()
+//		fmt.Println(onlyA, onlyB) // [0 1] [1000 1001]
+//	}
+type Sketch struct {
+	cells []cell
+}
+
+// Create a new empty Sketch able to recover about `diff` differences.
+//
+// This function will panic if diff is negative.
+func NewSketch(diff int) *Sketch {
+	if diff < 0 {
+		panic("Cannot size a sketch with a negative difference")
+	}
+
+	// Peeling reliably succeeds with about 1.5 cells per key once the table is large;
+	// small tables need proportionally more.
+	n := (diff*3/2 + 12 + hashes - 1) / hashes * hashes
+
+	return &Sketch{
+		cells: make([]cell, n),
+	}
+}
+
+// mix is the finalizer of splitmix64.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+func check(key uint64) uint64 {
+	return mix(key ^ 0x9e3779b97f4a7c15)
+}
+
+// index returns the cell of `key` in the i-th of the equal parts of the table, so the
+// cells of a key never coincide.
+func (s *Sketch) index(key uint64, i int) int {
+	part := len(s.cells) / hashes
+	return i*part + int(mix(key+uint64(i)+1)%uint64(part))
+}
+
+func (s *Sketch) update(key uint64, count int64) {
+	sum := check(key)
+	for i := range hashes {
+		c := &s.cells[s.index(key, i)]
+		c.count += count
+		c.keySum ^= key
+		c.hashSum ^= sum
+	}
+}
+
+// Adds a key to the sketch.
+//
+// Adding the same key twice adds it twice, so keys should be unique.
+func (s *Sketch) Add(key uint64) {
+	s.update(key, 1)
+}
+
+// The number of cells of the sketch.
+func (s *Sketch) Len() int {
+	return len(s.cells)
+}
+
+// Removes the keys of `other` from the sketch, leaving the keys only this sketch has with
+// a positive count and the keys only `other` has with a negative one.
+//
+// This function will panic if the sketches don't have the same number of cells.
+func (s *Sketch) Subtract(other *Sketch) {
+	if len(s.cells) != len(other.cells) {
+		panic("Cannot subtract sketches of different sizes")
+	}
+
+	for i, c := range other.cells {
+		s.cells[i].count -= c.count
+		s.cells[i].keySum ^= c.keySum
+		s.cells[i].hashSum ^= c.hashSum
+	}
+}
+
+// Recovers the keys of a subtracted sketch: `added` holds the keys only in the receiver
+// and `removed` the keys only in the subtracted sketch, both sorted.
+//
+// Returns ErrUndecodable if some keys could not be recovered. The sketch is emptied
+// while decoding either way.
+func (s *Sketch) Decode() (added, removed []uint64, err error) {
+	pure := func(c cell) bool {
+		return (c.count == 1 || c.count == -1) && c.hashSum == check(c.keySum)
+	}
+
+	queue := make([]int, 0, len(s.cells))
+	for i, c := range s.cells {
+		if pure(c) {
+			queue = append(queue, i)
+		}
+	}
+
+	for len(queue) > 0 {
+		i := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		c := s.cells[i]
+		if !pure(c) {
+			continue
+		}
+
+		if c.count > 0 {
+			added = append(added, c.keySum)
+		} else {
+			removed = append(removed, c.keySum)
+		}
+
+		s.update(c.keySum, -c.count)
+		for j := range hashes {
+			if k := s.index(c.keySum, j); pure(s.cells[k]) {
+				queue = append(queue, k)
+			}
+		}
+	}
+
+	for _, c := range s.cells {
+		if c != (cell{}) {
+			return nil, nil, ErrUndecodable
+		}
+	}
+
+	slices.Sort(added)
+	slices.Sort(removed)
+	return added, removed, nil
+}
+
+// Encodes the sketch as its number of cells followed by the cells.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, binary.MaxVarintLen64+len(s.cells)*(binary.MaxVarintLen64+16))
+	b = binary.AppendUvarint(b, uint64(len(s.cells)))
+
+	for _, c := range s.cells {
+		b = binary.AppendVarint(b, c.count)
+		b = binary.LittleEndian.AppendUint64(b, c.keySum)
+		b = binary.LittleEndian.AppendUint64(b, c.hashSum)
+	}
+
+	return b, nil
+}
+
+// Decodes a sketch encoded by MarshalBinary, replacing the sketch.
+func (s *Sketch) UnmarshalBinary(b []byte) error {
+	n, m := binary.Uvarint(b)
+	if m <= 0 || n == 0 || n%hashes != 0 || n > uint64(len(b)) {
+		return ErrMalformed
+	}
+	b = b[m:]
+
+	cells := make([]cell, n)
+	for i := range cells {
+		count, m := binary.Varint(b)
+		if m <= 0 || len(b) < m+16 {
+			return ErrMalformed
+		}
+
+		cells[i] = cell{
+			count:   count,
+			keySum:  binary.LittleEndian.Uint64(b[m:]),
+			hashSum: binary.LittleEndian.Uint64(b[m+8:]),
+		}
+		b = b[m+16:]
+	}
+
+	if len(b) != 0 {
+		return ErrMalformed
+	}
+
+	s.cells = cells
+	return nil
+}
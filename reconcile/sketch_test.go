@@ -0,0 +1,68 @@
+package reconcile_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set/reconcile"
+)
+
+func TestSketchDecode(t *testing.T) {
+	tests := []struct {
+		diff      int
+		onlyA     []uint64
+		onlyB     []uint64
+		decodable bool
+	}{
+		{diff: 0, decodable: true},
+		{diff: 10, onlyA: []uint64{0, 1, 2}, onlyB: []uint64{1 << 40}, decodable: true},
+		{diff: 200, onlyA: seq(10_000, 10_100), onlyB: seq(20_000, 20_100), decodable: true},
+		{diff: 2, onlyA: seq(10_000, 10_100), onlyB: seq(20_000, 20_100), decodable: false},
+	}
+
+	for i, test := range tests {
+		a := reconcile.NewSketch(test.diff)
+		b := reconcile.NewSketch(test.diff)
+		for _, k := range seq(100, 5_000) {
+			a.Add(k)
+			b.Add(k)
+		}
+		for _, k := range test.onlyA {
+			a.Add(k)
+		}
+		for _, k := range test.onlyB {
+			b.Add(k)
+		}
+
+		encoded, err := b.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Index: %d, Expected: no error, Got: %v", i, err)
+		}
+		var decoded reconcile.Sketch
+		if err := decoded.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("Index: %d, Expected: no error, Got: %v", i, err)
+		}
+
+		a.Subtract(&decoded)
+		onlyA, onlyB, err := a.Decode()
+		if !test.decodable {
+			if !errors.Is(err, reconcile.ErrUndecodable) {
+				t.Fatalf("Index: %d, Expected: %v, Got: %v", i, reconcile.ErrUndecodable, err)
+			}
+			continue
+		}
+
+		if err != nil || !slices.Equal(onlyA, test.onlyA) || !slices.Equal(onlyB, test.onlyB) {
+			t.Fatalf("Index: %d, Expected: %v %v, Got: %v %v %v", i, test.onlyA, test.onlyB, onlyA, onlyB, err)
+		}
+	}
+}
+
+func seq(from, to uint64) []uint64 {
+	var s []uint64
+	for k := from; k < to; k++ {
+		s = append(s, k)
+	}
+	return s
+}
@@ -0,0 +1,377 @@
+package set
+
+import (
+	"encoding/binary"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+)
+
+// A `Key` is a comparable value standing for the content of a Set, so it can be used as
+// a map key or an element of another Set where the Set itself can't.
+//
+// Sets with the same elements have the same Key. It's a canonical encoding of the
+// elements: each one is formatted with `%T:%#v` and the results are sorted, so unless the
+// elements hold pointers, keys don't depend on the process and can be stored with
+// MarshalBinary. Elements that format the
+// same, such as distinct pointers to equal structs, give different sets the same Key;
+// SetOfSets compares the elements as well.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		groups := map[set.Key[string]]string{}
+//		groups[set.FromSlice([]string{"ann", "bob"}).Key()] = "team a"
+//
+//		fmt.Println(groups[set.FromSlice([]string{"bob", "ann"}).Key()]) // team a
+//	}
+type Key[T comparable] struct {
+	enc string
+}
+
+// Returns the Key standing for the current elements of the set.
+func (s *Set[T]) Key() Key[T] {
+	encoded := make([]string, 0, s.Len())
+	for k := range s.set {
+		encoded = append(encoded, fmt.Sprintf("%T:%#v", k, k))
+	}
+	slices.Sort(encoded)
+
+	var b []byte
+	for _, e := range encoded {
+		b = binary.AppendUvarint(b, uint64(len(e)))
+		b = append(b, e...)
+	}
+
+	return Key[T]{
+		enc: string(b),
+	}
+}
+
+// Encodes the key, so it can be stored and compared with keys from other processes.
+func (k Key[T]) MarshalBinary() ([]byte, error) {
+	return []byte(k.enc), nil
+}
+
+// Decodes a key encoded with MarshalBinary.
+func (k *Key[T]) UnmarshalBinary(b []byte) error {
+	k.enc = string(b)
+	return nil
+}
+
+// A `SetOfSets` is a set whose elements are sets, deduplicated by their elements.
+//
+// It keeps the sets it's given rather than copies, and lookups return them, so a set
+// must not be modified while it's in a SetOfSets, as with a map key.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		groups := set.NewSetOfSets[int]()
+//		groups.Insert(set.FromSlice([]int{1, 2}))
+//		groups.Insert(set.FromSlice([]int{2, 1}))
+//		groups.Insert(set.FromSlice([]int{3}))
+//
+//		fmt.Println(groups.Len()) // 2
+//	}
+type SetOfSets[T comparable] struct {
+	// sets holds the sets by Key, with more than one only if their keys collide.
+	sets map[Key[T]][]*Set[T]
+	len  int
+}
+
+// Create a new empty SetOfSets.
+func NewSetOfSets[T comparable]() *SetOfSets[T] {
+	return &SetOfSets[T]{
+		sets: make(map[Key[T]][]*Set[T]),
+	}
+}
+
+func sameElements[T comparable](a, b *Set[T]) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+
+	for k := range a.set {
+		if !b.Contains(k) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (ss *SetOfSets[T]) find(s *Set[T]) (Key[T], int) {
+	key := s.Key()
+	for i, stored := range ss.sets[key] {
+		if sameElements(stored, s) {
+			return key, i
+		}
+	}
+
+	return key, -1
+}
+
+// Adds a set unless a set with the same elements is already there, and returns
+// whether it did.
+func (ss *SetOfSets[T]) Insert(s *Set[T]) bool {
+	key, i := ss.find(s)
+	if i >= 0 {
+		return false
+	}
+
+	ss.sets[key] = append(ss.sets[key], s)
+	ss.len++
+	return true
+}
+
+// Removes the set with the same elements as `s`.
+//
+// Removing a set that does not exists will result in nothing.
+func (ss *SetOfSets[T]) Delete(s *Set[T]) {
+	key, i := ss.find(s)
+	if i < 0 {
+		return
+	}
+
+	if bucket := ss.sets[key]; len(bucket) == 1 {
+		delete(ss.sets, key)
+	} else {
+		ss.sets[key] = append(bucket[:i], bucket[i+1:]...)
+	}
+	ss.len--
+}
+
+// Returns `true` if there is a set with the same elements as `s`.
+func (ss *SetOfSets[T]) Contains(s *Set[T]) bool {
+	_, i := ss.find(s)
+	return i >= 0
+}
+
+// Returns the stored set with the same elements as `s`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		original := set.FromSlice([]int{1, 2})
+//		groups := set.NewSetOfSets[int]()
+//		groups.Insert(original)
+//
+//		found, ok := groups.Get(set.FromSlice([]int{2, 1}))
+//		fmt.Println(ok, found == original) // true true
+//	}
+func (ss *SetOfSets[T]) Get(s *Set[T]) (*Set[T], bool) {
+	key, i := ss.find(s)
+	if i < 0 {
+		return nil, false
+	}
+
+	return ss.sets[key][i], true
+}
+
+// Returns the stored set whose Key is `key`.
+//
+// If several stored sets share the Key, as their elements format the same, the key
+// can't tell them apart and `false` is returned; use Get instead.
+func (ss *SetOfSets[T]) GetKey(key Key[T]) (*Set[T], bool) {
+	if bucket := ss.sets[key]; len(bucket) == 1 {
+		return bucket[0], true
+	}
+
+	return nil, false
+}
+
+// The number of sets the set has.
+func (ss *SetOfSets[T]) Len() int {
+	return ss.len
+}
+
+// Returns `true` if the set contains no sets.
+func (ss *SetOfSets[T]) Empty() bool {
+	return ss.len == 0
+}
+
+// A way to iterate through the stored sets using a range-loop, in an arbitrary order.
+func (ss *SetOfSets[T]) All() iter.Seq[*Set[T]] {
+	return func(yield func(*Set[T]) bool) {
+		for _, bucket := range ss.sets {
+			for _, s := range bucket {
+				if !yield(s) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns a stringified version of the set with sets in an arbitrary order
+func (ss SetOfSets[T]) String() string {
+	parts := make([]string, 0, ss.len)
+	for s := range ss.All() {
+		parts = append(parts, s.String())
+	}
+
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// Returns every subset of `s`, from the empty set to a copy of `s`.
+//
+// There are 2^n subsets of a set of n elements. This function will panic if the set
+// has more than 30 elements.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		subsets := set.Subsets(set.FromSlice([]int{1, 2, 3}))
+//		fmt.Println(subsets.Len()) // 8
+//	}
+func Subsets[T comparable](s *Set[T]) *SetOfSets[T] {
+	if s.Len() > 30 {
+		panic(fmt.Sprintf("Cannot compute the %d-element power set of a set", s.Len()))
+	}
+
+	result := NewSetOfSets[T]()
+	for subset := range PowerSet(s) {
+		result.Insert(subset)
+	}
+
+	return result
+}
+
+// Splits `s` into groups of the elements with the same key.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		parity := set.Partition(set.FromSlice([]int{1, 2, 3, 4}), func(k int) bool {
+//			return k%2 == 0
+//		})
+//		fmt.Println(parity.Len()) // 2
+//	}
+func Partition[T, K comparable](s *Set[T], key func(T) K) *SetOfSets[T] {
+	groups := make(map[K]*Set[T])
+	for k := range s.set {
+		g, ok := groups[key(k)]
+		if !ok {
+			g = New[T]()
+			groups[key(k)] = g
+		}
+		g.Insert(k)
+	}
+
+	result := NewSetOfSets[T]()
+	for _, g := range groups {
+		result.Insert(g)
+	}
+
+	return result
+}
+
+// Returns a sequence of every way to split `s` into non-empty groups.
+//
+// The number of partitions grows faster than exponentially with the size of the set,
+// so the sequence should be stopped early for anything but small sets.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		for p := range set.Partitions(set.FromSlice([]string{"a", "b", "c"})) {
+//			fmt.Println(p) // [[a b c]], [[a b] [c]], ...
+//		}
+//	}
+func Partitions[T comparable](s *Set[T]) iter.Seq[*SetOfSets[T]] {
+	return func(yield func(*SetOfSets[T]) bool) {
+		keys := s.Keys()
+		if len(keys) == 0 {
+			yield(NewSetOfSets[T]())
+			return
+		}
+
+		// group[i] is the group of keys[i], such that each group number is at most one
+		// more than the largest number before it, which lists every partition once.
+		group := make([]int, len(keys))
+		var next func(i, groups int) bool
+		next = func(i, groups int) bool {
+			if i == len(keys) {
+				return yield(partition(keys, group, groups))
+			}
+
+			for g := range groups + 1 {
+				group[i] = g
+				if !next(i+1, max(groups, g+1)) {
+					return false
+				}
+			}
+
+			return true
+		}
+
+		next(1, 1)
+	}
+}
+
+func partition[T comparable](keys []T, group []int, groups int) *SetOfSets[T] {
+	sets := make([]*Set[T], groups)
+	for i := range sets {
+		sets[i] = New[T]()
+	}
+	for i, k := range keys {
+		sets[group[i]].Insert(k)
+	}
+
+	result := NewSetOfSets[T]()
+	for _, g := range sets {
+		result.Insert(g)
+	}
+
+	return result
+}
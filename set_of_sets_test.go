@@ -0,0 +1,118 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/Jamlie/set"
+)
+
+func TestSetOfSets(t *testing.T) {
+	original := set.FromSlice([]int{1, 2, 3})
+	groups := set.NewSetOfSets[int]()
+
+	tests := []struct {
+		set      *set.Set[int]
+		inserted bool
+	}{
+		{set: original, inserted: true},
+		{set: set.FromSlice([]int{3, 2, 1}), inserted: false},
+		{set: set.New[int](), inserted: true},
+		{set: set.FromSlice([]int{1, 2}), inserted: true},
+	}
+
+	for i, test := range tests {
+		if got := groups.Insert(test.set); got != test.inserted {
+			t.Fatalf("Index: %d, Expected: %v, Got: %v", i, test.inserted, got)
+		}
+	}
+
+	if groups.Len() != 3 {
+		t.Fatalf("Expected: 3, Got: %d", groups.Len())
+	}
+
+	found, ok := groups.Get(set.FromSlice([]int{2, 3, 1}))
+	if !ok || found != original {
+		t.Fatalf("Expected: the original set, Got: %v, %v", found, ok)
+	}
+
+	if found, ok := groups.GetKey(set.FromSlice([]int{1, 2, 3}).Key()); !ok || found != original {
+		t.Fatalf("Expected: the original set, Got: %v, %v", found, ok)
+	}
+
+	groups.Delete(set.FromSlice([]int{1, 2}))
+	if groups.Len() != 2 || groups.Contains(set.FromSlice([]int{1, 2})) {
+		t.Fatalf("Expected: [1 2] deleted, Got: %s", groups)
+	}
+}
+
+func TestKey(t *testing.T) {
+	// the key is the sorted encoding of the elements, so it doesn't depend on the process
+	key, _ := set.FromSlice([]string{"b", "a"}).Key().MarshalBinary()
+	if expect := "\nstring:\"a\"\nstring:\"b\""; string(key) != expect {
+		t.Fatalf("Expected: %q, Got: %q", expect, key)
+	}
+
+	var decoded set.Key[string]
+	if err := decoded.UnmarshalBinary(key); err != nil || decoded != set.FromSlice([]string{"a", "b"}).Key() {
+		t.Fatalf("Expected: the same key after decoding, Got: %v", err)
+	}
+
+	if set.FromSlice([]any{1}).Key() == set.FromSlice([]any{int64(1)}).Key() {
+		t.Fatalf("Expected: different keys for elements of different types")
+	}
+
+	// distinct pointers to equal structs format the same, so their sets share a key
+	x, y := &person{Id: 1}, &person{Id: 1}
+	groups := set.NewSetOfSets[*person]()
+	groups.Insert(set.FromSlice([]*person{x}))
+	groups.Insert(set.FromSlice([]*person{y}))
+	if groups.Len() != 2 {
+		t.Fatalf("Expected: 2, Got: %d", groups.Len())
+	}
+	if found, ok := groups.GetKey(set.FromSlice([]*person{x}).Key()); ok {
+		t.Fatalf("Expected: no set for an ambiguous key, Got: %v", found)
+	}
+}
+
+func TestSubsetsAndPartitions(t *testing.T) {
+	s := set.FromSlice([]string{"a", "b", "c", "d"})
+
+	if subsets := set.Subsets(s); subsets.Len() != 16 || !subsets.Contains(set.FromSlice([]string{"b", "d"})) {
+		t.Fatalf("Expected: 16 subsets, Got: %d", subsets.Len())
+	}
+
+	vowels := set.Partition(s, func(k string) bool { return k == "a" })
+	if vowels.Len() != 2 || !vowels.Contains(set.FromSlice([]string{"b", "c", "d"})) {
+		t.Fatalf("Expected: [[a] [b c d]], Got: %s", vowels)
+	}
+
+	// The number of partitions of 4 elements is the Bell number 15.
+	seen := map[set.Key[string]]bool{}
+	count := 0
+	for p := range set.Partitions(s) {
+		union := set.New[string]()
+		size := 0
+		for g := range p.All() {
+			union.InsertSeq(g.All())
+			size += g.Len()
+		}
+		if size != 4 || union.Len() != 4 {
+			t.Fatalf("Expected: a partition of %s, Got: %s", s, p)
+		}
+
+		count++
+		seen[partitionKey(p)] = true
+	}
+
+	if count != 15 || len(seen) != 15 {
+		t.Fatalf("Expected: 15 distinct partitions, Got: %d, %d distinct", count, len(seen))
+	}
+}
+
+func partitionKey(p *set.SetOfSets[string]) set.Key[string] {
+	joined := set.New[string]()
+	for g := range p.All() {
+		joined.Insert(g.String())
+	}
+	return joined.Key()
+}
@@ -0,0 +1,286 @@
+package stream
+
+import (
+	"bufio"
+	"cmp"
+	"container/heap"
+	"encoding/gob"
+	"errors"
+	"io"
+	"iter"
+	"os"
+	"slices"
+)
+
+// maxMerge is the largest number of files merged, and so kept open, at once.
+const maxMerge = 64
+
+// A `Sorted` holds the result of an external merge sort.
+//
+// Sorted chunks are kept in temporary files until Close is called, so the
+// result can be iterated as many times as needed.
+type Sorted[T cmp.Ordered] struct {
+	chunk []T
+	files []string
+	err   error
+}
+
+// Sorts the elements of `seq` in ascending order, removing duplicates, while holding at most
+// `chunkSize` elements in memory.
+//
+// Every time `chunkSize` elements are read they are sorted and spilled to a temporary
+// file in `dir` (or the default directory for temporary files if `dir` is empty), and
+// the files are merged lazily when the result is iterated. At most 64 files are opened at
+// once: when more chunks are spilled, they are first merged by groups of 64 into larger
+// files. Elements are written to disk with `encoding/gob`. This function will panic if
+// chunkSize is not positive.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"log"
+//		"slices"
+//
+//		"github.com/Jamlie/set/stream"
+//	)
+//
+//	func main() {
+//		sorted, err := stream.Sort(slices.Values([]int{5, 3, 1, 3}), 2, "")
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//		defer sorted.Close()
+//
+//		for k := range sorted.All() {
+//			log.Println(k) // 1 3 5
+//		}
+//		if err := sorted.Err(); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+func Sort[T cmp.Ordered](seq iter.Seq[T], chunkSize int, dir string) (*Sorted[T], error) {
+	if chunkSize <= 0 {
+		panic("Cannot sort with a non-positive chunk size")
+	}
+
+	s := &Sorted[T]{}
+	chunk := make([]T, 0, min(chunkSize, 1024))
+
+	for k := range seq {
+		chunk = append(chunk, k)
+		if len(chunk) < chunkSize {
+			continue
+		}
+
+		if err := s.spill(chunk, dir); err != nil {
+			s.Close()
+			return nil, err
+		}
+		chunk = chunk[:0]
+	}
+
+	if len(s.files) == 0 {
+		slices.Sort(chunk)
+		s.chunk = slices.Compact(chunk)
+		return s, nil
+	}
+
+	if len(chunk) > 0 {
+		if err := s.spill(chunk, dir); err != nil {
+			s.Close()
+			return nil, err
+		}
+	}
+
+	if err := s.compact(dir); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// A way to iterate through the sorted elements using a range-loop.
+//
+// Errors reading the temporary files stop the iteration and are reported by Err.
+func (s *Sorted[T]) All() iter.Seq[T] {
+	if len(s.files) == 0 {
+		return slices.Values(s.chunk)
+	}
+
+	return Unique(s.merge(s.files))
+}
+
+// Returns the first error found while iterating, if any.
+func (s *Sorted[T]) Err() error {
+	return s.err
+}
+
+// Removes the temporary files. The result must not be iterated after calling Close.
+func (s *Sorted[T]) Close() error {
+	err := remove(s.files)
+	s.files = nil
+	s.chunk = nil
+
+	return err
+}
+
+func remove(files []string) error {
+	var errs []error
+	for _, name := range files {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *Sorted[T]) spill(chunk []T, dir string) error {
+	slices.Sort(chunk)
+	return s.write(slices.Values(slices.Compact(chunk)), dir)
+}
+
+// compact merges the files by groups of maxMerge until there are at most maxMerge of them.
+func (s *Sorted[T]) compact(dir string) error {
+	for len(s.files) > maxMerge {
+		files := s.files
+		s.files = nil
+
+		for group := range slices.Chunk(files, maxMerge) {
+			err := s.write(Unique(s.merge(group)), dir)
+			if err == nil {
+				err = s.err
+			}
+			if err != nil {
+				s.files = append(s.files, files...)
+				return err
+			}
+		}
+
+		if err := remove(files); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// write saves the elements of `seq` to a new temporary file in `dir`.
+func (s *Sorted[T]) write(seq iter.Seq[T], dir string) (err error) {
+	f, err := os.CreateTemp(dir, "stream-sort-*")
+	if err != nil {
+		return err
+	}
+	s.files = append(s.files, f.Name())
+
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	enc := gob.NewEncoder(w)
+	for k := range seq {
+		if err := enc.Encode(k); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// merge yields the elements of the sorted `files` in order, keeping all of them open.
+func (s *Sorted[T]) merge(files []string) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		h := make(cursors[T], 0, len(files))
+		defer func() {
+			for _, c := range h {
+				c.stop()
+			}
+		}()
+
+		for _, name := range files {
+			next, stop := iter.Pull(s.read(name))
+			c := &cursor[T]{next: next, stop: stop}
+			if c.advance() {
+				h = append(h, c)
+			} else {
+				stop()
+			}
+		}
+		heap.Init(&h)
+
+		for len(h) > 0 {
+			c := h[0]
+			if !yield(c.value) {
+				return
+			}
+
+			if c.advance() {
+				heap.Fix(&h, 0)
+			} else {
+				c.stop()
+				heap.Pop(&h)
+			}
+		}
+	}
+}
+
+func (s *Sorted[T]) read(name string) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		f, err := os.Open(name)
+		if err != nil {
+			s.err = err
+			return
+		}
+		defer f.Close()
+
+		dec := gob.NewDecoder(bufio.NewReader(f))
+		for {
+			var k T
+			if err := dec.Decode(&k); err != nil {
+				if err != io.EOF {
+					s.err = err
+				}
+				return
+			}
+
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+type cursor[T cmp.Ordered] struct {
+	value T
+	next  func() (T, bool)
+	stop  func()
+}
+
+func (c *cursor[T]) advance() bool {
+	var ok bool
+	c.value, ok = c.next()
+	return ok
+}
+
+type cursors[T cmp.Ordered] []*cursor[T]
+
+func (h cursors[T]) Len() int           { return len(h) }
+func (h cursors[T]) Less(i, j int) bool { return cmp.Less(h[i].value, h[j].value) }
+func (h cursors[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *cursors[T]) Push(x any) {
+	*h = append(*h, x.(*cursor[T]))
+}
+
+func (h *cursors[T]) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
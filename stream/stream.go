@@ -0,0 +1,187 @@
+// Package stream provides set operations over sorted sequences.
+//
+// The functions in this package never hold more than a few elements in memory, which
+// makes them suitable for datasets that don't fit in a Set, much like the Unix `comm`
+// command. Every input must be sorted in ascending order; duplicated elements are
+// treated as a single one. Unsorted input can be prepared with Sort, which spills
+// sorted chunks to temporary files.
+//
+// The results are `iter.Seq[T]`, so they can be fed to `set.Set.InsertSeq` once they
+// are small enough.
+package stream
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Returns a sequence yielding each element of a sorted sequence only once.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"slices"
+//
+//		"github.com/Jamlie/set/stream"
+//	)
+//
+//	func main() {
+//		v := stream.Unique(slices.Values([]int{1, 1, 2, 3, 3}))
+//		fmt.Println(slices.Collect(v)) // [1 2 3]
+//	}
+func Unique[T cmp.Ordered](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var prev T
+		first := true
+
+		for k := range seq {
+			if !first && k == prev {
+				continue
+			}
+			first = false
+			prev = k
+
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a sorted sequence of the elements that are in `a`, in `b` or in both.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"slices"
+//
+//		"github.com/Jamlie/set/stream"
+//	)
+//
+//	func main() {
+//		a := slices.Values([]int{1, 3, 5})
+//		b := slices.Values([]int{2, 3, 4})
+//		fmt.Println(slices.Collect(stream.Union(a, b))) // [1 2 3 4 5]
+//	}
+func Union[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return merge(a, b, true, true, true)
+}
+
+// Returns a sorted sequence of the elements that are in both `a` and `b`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"slices"
+//
+//		"github.com/Jamlie/set/stream"
+//	)
+//
+//	func main() {
+//		a := slices.Values([]int{1, 3, 5})
+//		b := slices.Values([]int{2, 3, 4})
+//		fmt.Println(slices.Collect(stream.Intersection(a, b))) // [3]
+//	}
+func Intersection[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return merge(a, b, false, false, true)
+}
+
+// Returns a sorted sequence of the elements that are in `a` but not in `b`.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"slices"
+//
+//		"github.com/Jamlie/set/stream"
+//	)
+//
+//	func main() {
+//		a := slices.Values([]int{1, 3, 5})
+//		b := slices.Values([]int{2, 3, 4})
+//		fmt.Println(slices.Collect(stream.Difference(a, b))) // [1 5]
+//	}
+func Difference[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return merge(a, b, true, false, false)
+}
+
+// Returns a sorted sequence of the elements that are in either `a` or `b`, but not in both.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//		"slices"
+//
+//		"github.com/Jamlie/set/stream"
+//	)
+//
+//	func main() {
+//		a := slices.Values([]int{1, 3, 5})
+//		b := slices.Values([]int{2, 3, 4})
+//		fmt.Println(slices.Collect(stream.SymmetricDifference(a, b))) // [1 2 4 5]
+//	}
+func SymmetricDifference[T cmp.Ordered](a, b iter.Seq[T]) iter.Seq[T] {
+	return merge(a, b, true, true, false)
+}
+
+// merge walks both sequences in lockstep, like `comm`, yielding the elements
+// found only in `a`, only in `b` and in both according to the flags.
+func merge[T cmp.Ordered](a, b iter.Seq[T], onlyA, onlyB, both bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nextA, stopA := iter.Pull(Unique(a))
+		defer stopA()
+		nextB, stopB := iter.Pull(Unique(b))
+		defer stopB()
+
+		x, okA := nextA()
+		y, okB := nextB()
+
+		for okA && okB {
+			switch c := cmp.Compare(x, y); {
+			case c < 0:
+				if onlyA && !yield(x) {
+					return
+				}
+				x, okA = nextA()
+			case c > 0:
+				if onlyB && !yield(y) {
+					return
+				}
+				y, okB = nextB()
+			default:
+				if both && !yield(x) {
+					return
+				}
+				x, okA = nextA()
+				y, okB = nextB()
+			}
+		}
+
+		for ; okA && onlyA; x, okA = nextA() {
+			if !yield(x) {
+				return
+			}
+		}
+
+		for ; okB && onlyB; y, okB = nextB() {
+			if !yield(y) {
+				return
+			}
+		}
+	}
+}
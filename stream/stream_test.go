@@ -0,0 +1,157 @@
+package stream_test
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set"
+	"github.com/Jamlie/set/stream"
+)
+
+func TestStreamOperations(t *testing.T) {
+	a := slices.Values([]int{1, 2, 2, 3, 5, 8})
+	b := slices.Values([]int{2, 3, 4, 8, 8, 9})
+
+	tests := []struct {
+		name   string
+		result []int
+		expect []int
+	}{
+		{
+			name:   "union",
+			result: slices.Collect(stream.Union(a, b)),
+			expect: []int{1, 2, 3, 4, 5, 8, 9},
+		},
+		{
+			name:   "intersection",
+			result: slices.Collect(stream.Intersection(a, b)),
+			expect: []int{2, 3, 8},
+		},
+		{
+			name:   "difference",
+			result: slices.Collect(stream.Difference(a, b)),
+			expect: []int{1, 5},
+		},
+		{
+			name:   "symmetric difference",
+			result: slices.Collect(stream.SymmetricDifference(a, b)),
+			expect: []int{1, 4, 5, 9},
+		},
+	}
+
+	for _, test := range tests {
+		if !slices.Equal(test.result, test.expect) {
+			t.Fatalf("%s: Expected: %v, Got: %v", test.name, test.expect, test.result)
+		}
+	}
+}
+
+func TestStreamEarlyStop(t *testing.T) {
+	a := slices.Values([]int{1, 2, 3})
+	b := slices.Values([]int{4, 5, 6})
+
+	var got []int
+	for k := range stream.Union(a, b) {
+		got = append(got, k)
+		if k == 4 {
+			break
+		}
+	}
+
+	if !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Fatalf("Expected: %v, Got: %v", []int{1, 2, 3, 4}, got)
+	}
+}
+
+func TestSort(t *testing.T) {
+	tests := []struct {
+		input     []string
+		chunkSize int
+		expect    []string
+	}{
+		{
+			input:     []string{"d", "a", "c", "a", "b"},
+			chunkSize: 10,
+			expect:    []string{"a", "b", "c", "d"},
+		},
+		{
+			input:     []string{"d", "a", "c", "a", "b", "e", "d"},
+			chunkSize: 2,
+			expect:    []string{"a", "b", "c", "d", "e"},
+		},
+	}
+
+	for i, test := range tests {
+		sorted, err := stream.Sort(slices.Values(test.input), test.chunkSize, t.TempDir())
+		if err != nil {
+			t.Fatalf("Index: %d, Expected: no error, Got: %v", i, err)
+		}
+
+		got := slices.Collect(sorted.All())
+		if err := sorted.Err(); err != nil {
+			t.Fatalf("Index: %d, Expected: no error, Got: %v", i, err)
+		}
+		if err := sorted.Close(); err != nil {
+			t.Fatalf("Index: %d, Expected: no error, Got: %v", i, err)
+		}
+
+		if !slices.Equal(got, test.expect) {
+			t.Fatalf("Index: %d, Expected: %v, Got: %v", i, test.expect, got)
+		}
+	}
+}
+
+func TestSortManyChunks(t *testing.T) {
+	dir := t.TempDir()
+	input := make([]int, 1_000)
+	for i := range input {
+		input[i] = (i * 7919) % 500
+	}
+
+	sorted, err := stream.Sort(slices.Values(input), 3, dir)
+	if err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	files, _ := os.ReadDir(dir)
+	if len(files) > 64 {
+		t.Fatalf("Expected: at most 64 files to merge, Got: %d", len(files))
+	}
+
+	got := slices.Collect(sorted.All())
+	if err := sorted.Err(); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+	if len(got) != 500 || !slices.IsSorted(got) || got[0] != 0 || got[499] != 499 {
+		t.Fatalf("Expected: 0 to 499 in order, Got: %v", got)
+	}
+
+	if err := sorted.Close(); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Fatalf("Expected: no temporary files left, Got: %d", len(files))
+	}
+}
+
+func TestSortIntoSet(t *testing.T) {
+	a, err := stream.Sort(slices.Values([]int{9, 1, 7, 3, 5}), 2, t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+	defer a.Close()
+
+	b, err := stream.Sort(slices.Values([]int{5, 4, 3, 2, 1}), 2, t.TempDir())
+	if err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+	defer b.Close()
+
+	s := set.New[int]()
+	s.InsertSeq(stream.Difference(a.All(), b.All()))
+
+	if s.Len() != 2 || !s.Contains(7) || !s.Contains(9) {
+		t.Fatalf("Expected: %v, Got: %s", []int{7, 9}, s)
+	}
+}
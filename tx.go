@@ -0,0 +1,149 @@
+package set
+
+import (
+	"errors"
+	"iter"
+)
+
+// ErrTxDone is returned when committing or rolling back a transaction that has
+// already been committed or rolled back.
+var ErrTxDone = errors.New("set: transaction has already been committed or rolled back")
+
+// A `Tx` records inserts and deletes to a Set and applies them all at once on Commit.
+//
+// Until then the set is left untouched, while the transaction's own reads see its writes.
+// A Tx is not safe for concurrent use.
+//
+// Examples:
+//
+//	package main
+//
+//	import (
+//		"fmt"
+//
+//		"github.com/Jamlie/set"
+//	)
+//
+//	func main() {
+//		v := set.FromSlice([]string{"a", "b"})
+//
+//		tx := v.Begin()
+//		tx.Delete("a")
+//		tx.Insert("c")
+//		fmt.Println(tx.Contains("c"), v.Contains("c")) // true false
+//
+//		if tx.Contains("b") {
+//			tx.Commit()
+//		} else {
+//			tx.Rollback()
+//		}
+//		fmt.Println(v.Contains("a"), v.Contains("c")) // false true
+//	}
+type Tx[T comparable] struct {
+	set *Set[T]
+	// changes maps every written key to whether it's in the set after the transaction.
+	changes map[T]bool
+	done    bool
+}
+
+// Starts a transaction on the set.
+//
+// The set should not be modified outside the transaction until it's committed or
+// rolled back, or the transaction's reads may not reflect it.
+func (s *Set[T]) Begin() *Tx[T] {
+	return &Tx[T]{
+		set:     s,
+		changes: make(map[T]bool),
+	}
+}
+
+func (tx *Tx[T]) write(k T, in bool) {
+	if tx.done {
+		panic("Cannot write to a transaction that has already been committed or rolled back")
+	}
+
+	tx.changes[k] = in
+}
+
+// Records the insertion of `k`.
+func (tx *Tx[T]) Insert(k T) {
+	tx.write(k, true)
+}
+
+// Records the deletion of `k`.
+func (tx *Tx[T]) Delete(k T) {
+	tx.write(k, false)
+}
+
+// Returns `true` if the set contains `k` once the transaction's writes are applied.
+func (tx *Tx[T]) Contains(k T) bool {
+	if in, ok := tx.changes[k]; ok {
+		return in
+	}
+
+	return tx.set.Contains(k)
+}
+
+// The number of elements the set has once the transaction's writes are applied.
+func (tx *Tx[T]) Len() int {
+	n := tx.set.Len()
+	for k, in := range tx.changes {
+		switch has := tx.set.Contains(k); {
+		case in && !has:
+			n++
+		case !in && has:
+			n--
+		}
+	}
+
+	return n
+}
+
+// A way to iterate through the set with the transaction's writes applied, using a range-loop.
+func (tx *Tx[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k := range tx.set.All() {
+			if in, ok := tx.changes[k]; ok && !in {
+				continue
+			}
+			if !yield(k) {
+				return
+			}
+		}
+
+		for k, in := range tx.changes {
+			if in && !tx.set.Contains(k) && !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Applies the transaction's writes to the set.
+func (tx *Tx[T]) Commit() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+
+	for k, in := range tx.changes {
+		if in {
+			tx.set.Insert(k)
+		} else {
+			tx.set.Delete(k)
+		}
+	}
+
+	return nil
+}
+
+// Discards the transaction's writes.
+func (tx *Tx[T]) Rollback() error {
+	if tx.done {
+		return ErrTxDone
+	}
+	tx.done = true
+
+	clear(tx.changes)
+	return nil
+}
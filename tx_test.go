@@ -0,0 +1,82 @@
+package set_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/Jamlie/set"
+)
+
+func TestTxCommit(t *testing.T) {
+	s := set.FromSlice([]int{1, 2, 3})
+
+	tx := s.Begin()
+	tx.Delete(1)
+	tx.Insert(4)
+	tx.Insert(2)
+
+	if !tx.Contains(4) || tx.Contains(1) || tx.Len() != 3 {
+		t.Fatalf("Expected: the transaction to see its writes, Got: %v", slices.Collect(tx.All()))
+	}
+
+	if !s.Contains(1) || s.Contains(4) {
+		t.Fatalf("Expected: %v, Got: %s", []int{1, 2, 3}, s)
+	}
+
+	if !sameSlice(slices.Collect(tx.All()), []int{2, 3, 4}) {
+		t.Fatalf("Expected: %v, Got: %v", []int{2, 3, 4}, slices.Collect(tx.All()))
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	if !sameSlice(s.Keys(), []int{2, 3, 4}) {
+		t.Fatalf("Expected: %v, Got: %s", []int{2, 3, 4}, s)
+	}
+
+	if err := tx.Rollback(); !errors.Is(err, set.ErrTxDone) {
+		t.Fatalf("Expected: %v, Got: %v", set.ErrTxDone, err)
+	}
+}
+
+func TestTxRollback(t *testing.T) {
+	s := set.FromSlice([]int{1, 2, 3})
+
+	tx := s.Begin()
+	tx.Delete(1)
+	tx.Insert(4)
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Expected: no error, Got: %v", err)
+	}
+
+	if !sameSlice(s.Keys(), []int{1, 2, 3}) {
+		t.Fatalf("Expected: %v, Got: %s", []int{1, 2, 3}, s)
+	}
+
+	if err := tx.Commit(); !errors.Is(err, set.ErrTxDone) {
+		t.Fatalf("Expected: %v, Got: %v", set.ErrTxDone, err)
+	}
+}
+
+func ExampleTx() {
+	v := set.FromSlice([]string{"a", "b"})
+
+	tx := v.Begin()
+	tx.Delete("a")
+	tx.Insert("c")
+	fmt.Println(tx.Contains("c"), v.Contains("c"))
+
+	if tx.Contains("b") {
+		tx.Commit()
+	} else {
+		tx.Rollback()
+	}
+	fmt.Println(v.Contains("a"), v.Contains("c"))
+	// Output:
+	// true false
+	// false true
+}